@@ -0,0 +1,200 @@
+package mojura
+
+import (
+	"github.com/mojura/mojura/filters"
+)
+
+var (
+	_ filterCursor   = &andCursor{}
+	_ distinctCursor = &andCursor{}
+)
+
+func newAndCursor(txn *Transaction, f *filters.AndFilter) (c filterCursor, err error) {
+	if len(f.Filters) == 0 {
+		// Empty conjunctions match all entries
+		return newEntriesFilterCursor(txn)
+	}
+
+	// Nested conjunctions are planned the same way as top level filters
+	var p queryPlan
+	if p, err = txn.newQueryPlan(f.Filters, false); err != nil {
+		return
+	}
+
+	var and andCursor
+	and.txn = txn
+	if and.primary, err = newPlannedFilterCursor(txn, p.primary, false, false); err != nil {
+		return
+	}
+
+	if and.primary == nopC {
+		// Primary cannot match any entries, neither can the conjunction
+		c = nopC
+		return
+	}
+
+	and.secondary = make([]filterCursor, 0, len(p.secondary))
+	for _, childFilter := range p.secondary {
+		var child filterCursor
+		if child, err = newFilterCursor(txn, childFilter); err != nil {
+			return
+		}
+
+		if child == nopC {
+			// Child cannot match any entries, neither can the conjunction
+			c = nopC
+			return
+		}
+
+		and.secondary = append(and.secondary, child)
+	}
+
+	c = &and
+	return
+}
+
+// andCursor iterates through the entries of its primary child which match all of its secondary children
+type andCursor struct {
+	txn *Transaction
+
+	primary   filterCursor
+	secondary []filterCursor
+}
+
+func (c *andCursor) enableDistinct(reverse bool) {
+	if dc, ok := c.primary.(distinctCursor); ok {
+		dc.enableDistinct(reverse)
+	}
+}
+
+func (c *andCursor) isMatch(entryID []byte, reverse bool) (ok bool, err error) {
+	for _, child := range c.secondary {
+		if reverse {
+			ok, err = child.HasReverse(entryID)
+		} else {
+			ok, err = child.HasForward(entryID)
+		}
+
+		if err != nil || !ok {
+			return
+		}
+	}
+
+	return true, nil
+}
+
+func (c *andCursor) nextUntilMatch(entryID []byte, iteratingErr error) (matchingEntryID []byte, err error) {
+	var ok bool
+	for err = iteratingErr; err == nil; entryID, err = c.primary.Next() {
+		if ok, err = c.isMatch(entryID, false); err != nil {
+			return
+		}
+
+		if ok {
+			matchingEntryID = entryID
+			return
+		}
+	}
+
+	return
+}
+
+func (c *andCursor) prevUntilMatch(entryID []byte, iteratingErr error) (matchingEntryID []byte, err error) {
+	var ok bool
+	for err = iteratingErr; err == nil; entryID, err = c.primary.Prev() {
+		if ok, err = c.isMatch(entryID, true); err != nil {
+			return
+		}
+
+		if ok {
+			matchingEntryID = entryID
+			return
+		}
+	}
+
+	return
+}
+
+func (c *andCursor) getCurrentRelationshipID() (relationshipID string) {
+	return c.primary.getCurrentRelationshipID()
+}
+
+// SeekForward will seek the provided ID in a forward direction
+func (c *andCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.nextUntilMatch(c.primary.SeekForward(relationshipID, seekID))
+}
+
+// SeekReverse will seek the provided ID in a reverse direction
+func (c *andCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.prevUntilMatch(c.primary.SeekReverse(relationshipID, seekID))
+}
+
+// First will return the first entry
+func (c *andCursor) First() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.nextUntilMatch(c.primary.First())
+}
+
+// Last will return the last entry
+func (c *andCursor) Last() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.prevUntilMatch(c.primary.Last())
+}
+
+// Next will return the next entry
+func (c *andCursor) Next() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.nextUntilMatch(c.primary.Next())
+}
+
+// Prev will return the previous entry
+func (c *andCursor) Prev() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.prevUntilMatch(c.primary.Prev())
+}
+
+// HasForward will determine if an entry exists in a forward direction
+func (c *andCursor) HasForward(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	if ok, err = c.primary.HasForward(entryID); err != nil || !ok {
+		return
+	}
+
+	return c.isMatch(entryID, false)
+}
+
+// HasReverse will determine if an entry exists in a reverse direction
+func (c *andCursor) HasReverse(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	if ok, err = c.primary.HasReverse(entryID); err != nil || !ok {
+		return
+	}
+
+	return c.isMatch(entryID, true)
+}
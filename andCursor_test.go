@@ -0,0 +1,140 @@
+package mojura
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func Test_andCursor(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if err = testInsertNestedEntries(m); err != nil {
+		t.Fatal(err)
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters: []Filter{
+				filters.Or(
+					filters.Match("users", "user_1"),
+					filters.And(filters.Match("groups", "group_1"), filters.Match("contacts", "contact_1")),
+				),
+			},
+			expected: []string{"00000001", "00000002", "00000003"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(
+					filters.Match("users", "user_1"),
+					filters.And(filters.Match("groups", "group_1"), filters.Match("contacts", "contact_1")),
+				),
+			},
+			reverse:  true,
+			expected: []string{"00000003", "00000002", "00000001"},
+		},
+		{
+			filters: []Filter{
+				filters.And(filters.Match("groups", "group_1"), filters.Or(filters.Match("users", "user_2"), filters.Match("users", "user_3"))),
+			},
+			expected: []string{"00000002", "00000004"},
+		},
+		{
+			filters: []Filter{
+				filters.And(filters.GreaterThanOrEqualTo("tags", "b"), filters.Match("groups", "group_1")),
+			},
+			reverse:  true,
+			expected: []string{"00000004", "00000002"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(
+					filters.And(filters.GreaterThanOrEqualTo("tags", "b"), filters.Match("groups", "group_1")),
+					filters.Match("users", "user_0"),
+				),
+			},
+			expected: []string{"00000000", "00000002", "00000004"},
+		},
+		{
+			filters: []Filter{
+				filters.And(filters.Match("groups", "group_1"), filters.Match("users", "user_404")),
+			},
+			expected: []string{},
+		},
+		{
+			filters:  []Filter{filters.And()},
+			expected: []string{"00000000", "00000001", "00000002", "00000003", "00000004"},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
+func Test_andCursor_Has(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if err = testInsertNestedEntries(m); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		var cur filterCursor
+		f := filters.And(filters.Match("groups", "group_1"), filters.GreaterThanOrEqualTo("tags", "b"))
+		if cur, err = newAndCursor(txn, f); err != nil {
+			return
+		}
+
+		expected := map[string]bool{"00000000": false, "00000001": false, "00000002": true, "00000003": false, "00000004": true}
+		for id, isExpected := range expected {
+			var ok bool
+			if ok, err = cur.HasForward([]byte(id)); err != nil {
+				return
+			}
+
+			if ok != isExpected {
+				return fmt.Errorf("invalid has value for <%s>, expected %v and received %v", id, isExpected, ok)
+			}
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testInsertNestedEntries will insert the entries used by the nested filter tests
+func testInsertNestedEntries(m *Mojura) (err error) {
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0", "a"),
+		newTestStruct("user_1", "contact_2", "group_1", "1"),
+		newTestStruct("user_2", "contact_1", "group_1", "2", "b"),
+		newTestStruct("user_1", "contact_1", "group_0", "3", "a", "b"),
+		newTestStruct("user_3", "contact_3", "group_1", "4", "c"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			return
+		}
+	}
+
+	return
+}
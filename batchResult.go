@@ -0,0 +1,14 @@
+package mojura
+
+// BatchResult represents the result of a batch call
+type BatchResult struct {
+	// Err is the error returned for the call
+	Err error `json:"err"`
+	// Attempts is the number of times the call was ran
+	Attempts int `json:"attempts"`
+}
+
+// Retried will return whether or not the call was ran more than once
+func (b *BatchResult) Retried() (retried bool) {
+	return b.Attempts > 1
+}
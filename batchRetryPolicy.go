@@ -0,0 +1,92 @@
+package mojura
+
+import (
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// BatchRetryPrefix will re-run the calls prior to the failing call and then run the calls
+	// which follow the failing call
+	BatchRetryPrefix BatchRetryStrategy = iota
+	// BatchRetryBisect will split a failing batch in half and run each half as its own batch until
+	// the failing calls have been isolated
+	BatchRetryBisect
+	// BatchRetryIsolate will notify the failing call of its error and re-run the rest of the batch together
+	BatchRetryIsolate
+)
+
+const (
+	// ErrInvalidBatchRetryStrategy is returned when an unsupported batch retry strategy is provided
+	ErrInvalidBatchRetryStrategy = errors.Error("invalid batch retry strategy")
+)
+
+// BatchRetryStrategy represents the strategy used to isolate a failing call within a batch
+type BatchRetryStrategy uint8
+
+// Validate will validate a batch retry strategy
+func (b BatchRetryStrategy) Validate() (err error) {
+	switch b {
+	case BatchRetryPrefix:
+	case BatchRetryBisect:
+	case BatchRetryIsolate:
+
+	default:
+		return ErrInvalidBatchRetryStrategy
+	}
+
+	return
+}
+
+// BatchRetryPolicy represents the policy used when a batch encounters a failure
+type BatchRetryPolicy struct {
+	// Strategy determines how a failing call is isolated from the rest of a batch
+	Strategy BatchRetryStrategy
+	// MaxAttempts is the maximum number of times a call will be re-ran after its initial run
+	// Note: A value of zero disables retries, calls which share a batch with a failing call
+	// will be notified of the batch error
+	MaxAttempts int
+
+	// Backoff is the initial duration to wait before re-running a batch which failed due to a
+	// transient backend error. The duration doubles for each subsequent attempt
+	Backoff time.Duration
+	// MaxBackoff is the maximum duration to wait before re-running a batch
+	MaxBackoff time.Duration
+	// IsTransient determines if a backend error is transient and can be retried
+	// Note: When unset, all backend errors other than errors.ErrIsClosed are considered transient
+	IsTransient func(error) bool
+}
+
+// Validate will validate a batch retry policy
+func (b *BatchRetryPolicy) Validate() (err error) {
+	return b.Strategy.Validate()
+}
+
+func (b *BatchRetryPolicy) isTransient(err error) (ok bool) {
+	if err == errors.ErrIsClosed {
+		return false
+	}
+
+	if b.IsTransient == nil {
+		return true
+	}
+
+	return b.IsTransient(err)
+}
+
+func (b *BatchRetryPolicy) isExhausted(attempts int) (ok bool) {
+	// Note: The first attempt is the initial run and is not considered a retry
+	return attempts > b.MaxAttempts
+}
+
+func (b *BatchRetryPolicy) getBackoff(attempts int) (backoff time.Duration) {
+	backoff = b.Backoff
+	for i := 1; i < attempts; i++ {
+		if backoff *= 2; backoff >= b.MaxBackoff {
+			return b.MaxBackoff
+		}
+	}
+
+	return
+}
@@ -22,15 +22,27 @@ type batcher struct {
 
 	timer *time.Timer
 	calls []call
+	// Calls which are waiting for their backoff before being re-ran
+	pending sync.WaitGroup
+
+	closed bool
 }
 
 func (b *batcher) performCalls(txn *Transaction, cs calls) (failIndex int, err error) {
 	failIndex = -1
 	for i, c := range cs {
-		if err = recoverCall(txn, c.fn); err != nil {
+		// Increment the number of attempts for the current call
+		cs[i].attempts++
+		// Ensure each call is provided a transaction using its own context
+		if err = recoverCall(txn.withContext(c.ctx), c.fn); err != nil {
+			// Retain the error so the call is notified of its own error once exhausted
+			cs[i].err = err
 			failIndex = i
 			return
 		}
+
+		// Call succeeded, it is not responsible for any failure of the batch
+		cs[i].err = nil
 	}
 
 	return
@@ -51,56 +63,105 @@ func (b *batcher) clearTimer() {
 // run performs the transactions in the batch and communicates results
 // back to DB.Batch.
 func (b *batcher) run(cs calls) {
-	if len(cs) == 0 {
+	// Remove any calls which were cancelled before the flush
+	if cs = cs.removeDone(); len(cs) == 0 {
 		// We have no calls to run, bail out
 		return
 	}
 
 	var failIndex int
-	err := b.m.Transaction(context.Background(), func(txn *Transaction) (err error) {
+	err := b.m.writeTransaction(context.Background(), func(txn *Transaction) (err error) {
 		failIndex, err = b.performCalls(txn, cs)
 		return
 	})
 
-	if err == errors.ErrIsClosed {
+	switch {
+	case err == nil:
+		// We successfully batched our list of calls without error, notify all calls of nil error status
+		cs.notifyAll(nil)
+	case err == errors.ErrIsClosed:
 		cs.notifyAll(err)
-		return
+	case failIndex == -1:
+		// Our calls succeeded, but the backend failed to complete the transaction
+		b.handleBackendFailure(cs, err)
+
+	default:
+		b.handleCallFailure(cs, failIndex, err)
 	}
+}
 
-	// Check to see if we had no failures in our batch
-	if failIndex == -1 {
-		// We successfully batched our list of calls without error, notify all calls of nil error status
-		cs.notifyAll(nil)
+func (b *batcher) handleBackendFailure(cs calls, err error) {
+	policy := &b.m.opts.BatchRetryPolicy
+	if !policy.isTransient(err) {
+		// Error is not transient, notify all calls of the error
+		cs.notifyAll(err)
 		return
 	}
 
-	// Create group for successful calls
-	successful := cs[:failIndex]
+	// Remove any calls which have no retry attempts remaining
+	if cs = cs.removeExhausted(policy, err); len(cs) == 0 {
+		return
+	}
 
-	// Attempt to retry the successful group before the failing call
-	b.retry(successful, err)
+	// Wait for the backoff duration before re-running the calls
+	b.runAfter(cs, policy.getBackoff(cs.maxAttempts()))
+}
 
-	// Send error down error channel to call who caused issue
-	cs[failIndex].notify(err)
+// runAfter will re-run the calls once the delay has passed
+// Note: The calls are ran outside of the batcher lock, new calls can be appended during the delay
+func (b *batcher) runAfter(cs calls, delay time.Duration) {
+	// Copy the calls, the calls buffer is re-used once it has been flushed
+	pending := make(calls, len(cs))
+	copy(pending, cs)
+
+	b.pending.Add(1)
+	time.AfterFunc(delay, func() {
+		defer b.pending.Done()
+		b.run(pending)
+	})
+}
 
-	// Create group for remaining calls
-	remaining := cs[failIndex+1:]
+func (b *batcher) handleCallFailure(cs calls, failIndex int, err error) {
+	if len(cs) == 1 {
+		// The failing call was ran alone, send error down error channel to the call
+		cs[0].notify(err)
+		return
+	}
 
-	// Run the remaining calls
-	b.run(remaining)
+	switch b.m.opts.BatchRetryPolicy.Strategy {
+	case BatchRetryBisect:
+		// Split the batch in half and run each half as its own batch
+		mid := len(cs) / 2
+		b.retry(cs[:mid], err)
+		b.retry(cs[mid:], err)
+
+	case BatchRetryIsolate:
+		// Send error down error channel to call who caused issue
+		cs[failIndex].notify(err)
+		// Attempt to retry the batch without the failing call
+		b.retry(cs.without(failIndex), err)
+
+	default:
+		// Attempt to retry the successful group before the failing call
+		b.retry(cs[:failIndex], err)
+		// Send error down error channel to call who caused issue
+		cs[failIndex].notify(err)
+		// Run the remaining calls
+		b.run(cs[failIndex+1:])
+	}
 }
 
 func (b *batcher) retry(cs calls, err error) {
-	if b.m.opts.RetryBatchFail {
-		// Re-run the successful portion
-		// Note: This is expected to pass
-		b.run(cs)
+	groupErr := fmt.Errorf("error occurred within batch, but not within this request: %v", err)
+	// Remove any calls which have no retry attempts remaining
+	// Note: Calls which caused a failure are notified of their own error rather than the group error
+	if cs = cs.removeExhausted(&b.m.opts.BatchRetryPolicy, groupErr); len(cs) == 0 {
 		return
 	}
 
-	groupErr := fmt.Errorf("error occurred within batch, but not within this request: %v", err)
-	// Notify group
-	cs.notifyAll(groupErr)
+	// Re-run the calls
+	// Note: This is expected to pass
+	b.run(cs)
 }
 
 func (b *batcher) flush() {
@@ -114,14 +175,21 @@ func (b *batcher) flush() {
 	b.calls = b.calls[:0]
 }
 
-func (b *batcher) Append(ctx context.Context, fn TransactionFn) (errC chan error) {
+func (b *batcher) Append(ctx context.Context, fn TransactionFn) (errC chan BatchResult) {
 	b.mux.Lock()
 	defer b.mux.Unlock()
 
 	var c call
 	c.fn = fn
 	c.ctx = ctx
-	c.errC = make(chan error, 1)
+	c.errC = make(chan BatchResult, 1)
+	errC = c.errC
+
+	if b.closed {
+		// Batcher has been closed, notify call and return
+		c.notify(errors.ErrIsClosed)
+		return
+	}
 
 	// Append calls to calls buffer
 	b.calls = append(b.calls, c)
@@ -138,7 +206,7 @@ func (b *batcher) Append(ctx context.Context, fn TransactionFn) (errC chan error
 		b.timer = time.AfterFunc(b.m.opts.MaxBatchDuration, b.Run)
 	}
 
-	return c.errC
+	return
 }
 
 // Run triggers the current set of calls to be ran
@@ -149,3 +217,27 @@ func (b *batcher) Run() {
 	// Flush the calls buffer
 	b.flush()
 }
+
+// Close will stop the batcher from accepting new calls and flush any pending calls. The returned
+// channel is closed once the pending calls (and any in-flight batch or retry) have completed
+func (b *batcher) Close() (drained chan struct{}) {
+	drained = make(chan struct{})
+	go func() {
+		defer close(drained)
+		b.close()
+		// Wait for any calls which are waiting for their backoff to be re-ran
+		b.pending.Wait()
+	}()
+
+	return
+}
+
+func (b *batcher) close() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	// Stop accepting new calls
+	b.closed = true
+	// Flush the calls buffer
+	b.flush()
+}
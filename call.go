@@ -5,11 +5,28 @@ import "context"
 type call struct {
 	fn   TransactionFn
 	ctx  context.Context
-	errC chan error
+	errC chan BatchResult
+
+	attempts int
+	// Error caused by the most recent run of the call, nil when the call succeeded
+	err error
+}
+
+func (c *call) isDone() (err error) {
+	if !isDone(c.ctx) {
+		// Context is still active, return
+		return
+	}
+
+	return c.ctx.Err()
 }
 
 func (c *call) notify(err error) {
+	var r BatchResult
+	r.Err = err
+	r.Attempts = c.attempts
+
 	c.fn = nil
-	c.errC <- err
+	c.errC <- r
 	close(c.errC)
 }
@@ -7,3 +7,58 @@ func (c calls) notifyAll(err error) {
 		call.notify(err)
 	}
 }
+
+// removeDone will notify and remove any calls whose context has ended
+func (c calls) removeDone() (active calls) {
+	active = c[:0]
+	for _, call := range c {
+		if err := call.isDone(); err != nil {
+			// Call context has ended, notify call of context error
+			call.notify(err)
+			continue
+		}
+
+		active = append(active, call)
+	}
+
+	return
+}
+
+// removeExhausted will notify and remove any calls which have exhausted their retry attempts
+// Note: Calls which caused a failure on their most recent run are notified of their own error
+func (c calls) removeExhausted(p *BatchRetryPolicy, err error) (active calls) {
+	active = c[:0]
+	for _, call := range c {
+		switch {
+		case !p.isExhausted(call.attempts):
+			active = append(active, call)
+		case call.err != nil:
+			// Call has no retry attempts remaining, notify call of the error it caused
+			call.notify(call.err)
+
+		default:
+			// Call has no retry attempts remaining, notify call of provided error
+			call.notify(err)
+		}
+	}
+
+	return
+}
+
+// without will return a copy of the calls with the provided index removed
+func (c calls) without(index int) (out calls) {
+	out = make(calls, 0, len(c)-1)
+	out = append(out, c[:index]...)
+	out = append(out, c[index+1:]...)
+	return
+}
+
+func (c calls) maxAttempts() (max int) {
+	for _, call := range c {
+		if call.attempts > max {
+			max = call.attempts
+		}
+	}
+
+	return
+}
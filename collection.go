@@ -0,0 +1,225 @@
+package mojura
+
+import (
+	"context"
+	"reflect"
+)
+
+// NewCollection will return a new instance of a typed Collection
+func NewCollection[T Value](name, dir string, relationships ...string) (c *Collection[T], err error) {
+	return NewCollectionWithOpts[T](name, dir, defaultOpts, relationships...)
+}
+
+// NewCollectionWithOpts will return a new instance of a typed Collection
+func NewCollectionWithOpts[T Value](name, dir string, opts Opts, relationships ...string) (c *Collection[T], err error) {
+	var example T
+	if example, err = newCollectionExample[T](); err != nil {
+		return
+	}
+
+	var m *Mojura
+	if m, err = NewWithOpts(name, dir, example, opts, relationships...); err != nil {
+		return
+	}
+
+	return NewCollectionFromMojura[T](m)
+}
+
+// NewCollectionFromMojura will return a new instance of a typed Collection which wraps an existing
+// instance of Mojura
+// Note: ErrInvalidType is returned if the entry type of Mojura does not match the Collection type
+func NewCollectionFromMojura[T Value](m *Mojura) (cp *Collection[T], err error) {
+	var example T
+	if example, err = newCollectionExample[T](); err != nil {
+		return
+	}
+
+	if getMojuraType(example) != m.entryType {
+		err = ErrInvalidType
+		return
+	}
+
+	var c Collection[T]
+	c.m = m
+	cp = &c
+	return
+}
+
+func newCollectionExample[T Value]() (example T, err error) {
+	t := reflect.TypeOf(example)
+	if t == nil || !isPointer(t) {
+		// Collection types are expected to be pointers
+		err = ErrInvalidType
+		return
+	}
+
+	// Create a non-nil example of the collection type
+	example = reflect.New(t.Elem()).Interface().(T)
+	return
+}
+
+// Collection is a typed wrapper for Mojura
+type Collection[T Value] struct {
+	m *Mojura
+}
+
+// Mojura will return the underlying instance of Mojura
+func (c *Collection[T]) Mojura() (m *Mojura) {
+	return c.m
+}
+
+// New will insert a new entry with the given value and the associated relationships
+func (c *Collection[T]) New(val T) (entryID string, err error) {
+	return c.m.New(val)
+}
+
+// Exists will notiy if an entry exists for a given entry ID
+func (c *Collection[T]) Exists(entryID string) (exists bool, err error) {
+	return c.m.Exists(entryID)
+}
+
+// Get will attempt to get an entry by ID
+func (c *Collection[T]) Get(entryID string) (val T, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		val, err = txn.Get(entryID)
+		return
+	})
+
+	return
+}
+
+// GetFiltered will attempt to get the filtered entries
+func (c *Collection[T]) GetFiltered(o *FilteringOpts) (entries []T, lastID string, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		entries, lastID, err = txn.GetFiltered(o)
+		return
+	})
+
+	return
+}
+
+// GetPage will attempt to get a page of filtered entries along with the tokens of the next and previous pages
+func (c *Collection[T]) GetPage(o *FilteringOpts) (entries []T, page Page, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		entries, page, err = txn.GetPage(o)
+		return
+	})
+
+	return
+}
+
+// GetFilteredWithIncluded will attempt to get the filtered entries along with the related entries
+// for the relationship keys set within FilteringOpts.Include
+func (c *Collection[T]) GetFilteredWithIncluded(o *FilteringOpts) (entries []T, lastID string, included Included, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		entries, lastID, included, err = txn.GetFilteredWithIncluded(o)
+		return
+	})
+
+	return
+}
+
+// Explain will execute the filtered query and describe how it was executed
+func (c *Collection[T]) Explain(o *FilteringOpts) (e *Explanation, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		e, err = txn.Explain(o)
+		return
+	})
+
+	return
+}
+
+// GetFirst will attempt to get the first entry which matches the provided filters
+// Note: Will return ErrEntryNotFound if no match is found
+func (c *Collection[T]) GetFirst(o *IteratingOpts) (val T, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		val, err = txn.GetFirst(o)
+		return
+	})
+
+	return
+}
+
+// GetLast will attempt to get the last entry which matches the provided filters
+// Note: Will return ErrEntryNotFound if no match is found
+func (c *Collection[T]) GetLast(o *IteratingOpts) (val T, err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		val, err = txn.GetLast(o)
+		return
+	})
+
+	return
+}
+
+// ForEach will iterate through each of the entries
+func (c *Collection[T]) ForEach(fn func(T) error, o *IteratingOpts) (err error) {
+	err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		return txn.ForEach(fn, o)
+	})
+
+	return
+}
+
+// ForEachID will iterate through each of the entry IDs
+func (c *Collection[T]) ForEachID(fn ForEachIDFn, o *IteratingOpts) (err error) {
+	return c.m.ForEachID(fn, o)
+}
+
+// Cursor will return an iterating cursor
+func (c *Collection[T]) Cursor(fn func(*TypedCursor[T]) error, fs ...Filter) (err error) {
+	if err = c.ReadTransaction(context.Background(), func(txn *CollectionTransaction[T]) (err error) {
+		var cur *TypedCursor[T]
+		if cur, err = txn.Cursor(fs...); err != nil {
+			return
+		}
+
+		return fn(cur)
+	}); err == Break {
+		err = nil
+	}
+
+	return
+}
+
+// Put will place an entry at a given entry ID
+// Note: This will not check to see if the entry exists beforehand. If this functionality
+// is needed, look into using the Edit method
+func (c *Collection[T]) Put(entryID string, val T) (err error) {
+	return c.m.Put(entryID, val)
+}
+
+// Edit will attempt to edit an entry by ID
+func (c *Collection[T]) Edit(entryID string, val T) (err error) {
+	return c.m.Edit(entryID, val)
+}
+
+// Remove will remove a relationship ID and its related relationship IDs
+func (c *Collection[T]) Remove(entryID string) (err error) {
+	return c.m.Remove(entryID)
+}
+
+// Transaction will initialize a typed transaction
+func (c *Collection[T]) Transaction(ctx context.Context, fn func(*CollectionTransaction[T]) error) (err error) {
+	return c.m.Transaction(ctx, func(txn *Transaction) (err error) {
+		return fn(newCollectionTransaction[T](txn))
+	})
+}
+
+// ReadTransaction will initialize a typed read-only transaction
+func (c *Collection[T]) ReadTransaction(ctx context.Context, fn func(*CollectionTransaction[T]) error) (err error) {
+	return c.m.ReadTransaction(ctx, func(txn *Transaction) (err error) {
+		return fn(newCollectionTransaction[T](txn))
+	})
+}
+
+// Batch will initialize a typed batch
+func (c *Collection[T]) Batch(ctx context.Context, fn func(*CollectionTransaction[T]) error) (err error) {
+	return c.m.Batch(ctx, func(txn *Transaction) (err error) {
+		return fn(newCollectionTransaction[T](txn))
+	})
+}
+
+// Close will close the underlying instance of Mojura
+func (c *Collection[T]) Close() (err error) {
+	return c.m.Close()
+}
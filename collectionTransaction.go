@@ -0,0 +1,169 @@
+package mojura
+
+func newCollectionTransaction[T Value](txn *Transaction) *CollectionTransaction[T] {
+	var c CollectionTransaction[T]
+	c.txn = txn
+	return &c
+}
+
+// CollectionTransaction is a typed wrapper for Transaction
+type CollectionTransaction[T Value] struct {
+	txn *Transaction
+}
+
+func (c *CollectionTransaction[T]) newValue() (val T) {
+	return c.txn.m.newEntryValue().(T)
+}
+
+// Transaction will return the underlying transaction
+func (c *CollectionTransaction[T]) Transaction() (txn *Transaction) {
+	return c.txn
+}
+
+// New will insert a new entry with the given value and the associated relationships
+func (c *CollectionTransaction[T]) New(val T) (entryID string, err error) {
+	return c.txn.New(val)
+}
+
+// Exists will notiy if an entry exists for a given entry ID
+func (c *CollectionTransaction[T]) Exists(entryID string) (exists bool, err error) {
+	return c.txn.Exists(entryID)
+}
+
+// Get will attempt to get an entry by ID
+func (c *CollectionTransaction[T]) Get(entryID string) (val T, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	v := c.newValue()
+	if err = c.txn.Get(entryID, v); err != nil {
+		return
+	}
+
+	val = v
+	return
+}
+
+// GetFiltered will attempt to get all entries associated with a set of given filters
+func (c *CollectionTransaction[T]) GetFiltered(o *FilteringOpts) (entries []T, lastID string, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	lastID, err = c.txn.getFiltered(o, func(val Value) {
+		entries = append(entries, val.(T))
+	})
+
+	return
+}
+
+// GetPage will attempt to get a page of entries associated with a set of given filters along with the
+// tokens of the next and previous pages (see FilteringOpts.PageToken)
+func (c *CollectionTransaction[T]) GetPage(o *FilteringOpts) (entries []T, page Page, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	page, err = c.txn.getPage(o, func(val Value) {
+		entries = append(entries, val.(T))
+	})
+
+	return
+}
+
+// GetFilteredWithIncluded will attempt to get all entries associated with a set of given filters along with
+// the related entries for the relationship keys set within FilteringOpts.Include
+func (c *CollectionTransaction[T]) GetFilteredWithIncluded(o *FilteringOpts) (entries []T, lastID string, included Included, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	var vals []Value
+	if lastID, err = c.txn.getFiltered(o, func(val Value) {
+		entries = append(entries, val.(T))
+		vals = append(vals, val)
+	}); err != nil {
+		return
+	}
+
+	included, err = c.txn.getIncluded(o, vals)
+	return
+}
+
+// Explain will execute the filtered query and describe how it was executed
+func (c *CollectionTransaction[T]) Explain(o *FilteringOpts) (e *Explanation, err error) {
+	return c.txn.Explain(o)
+}
+
+// GetFirst will attempt to get the first entry associated with a set of given filters
+// Note: Will return ErrEntryNotFound if no match is found
+func (c *CollectionTransaction[T]) GetFirst(o *IteratingOpts) (val T, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	v := c.newValue()
+	if err = c.txn.GetFirst(v, o); err != nil {
+		return
+	}
+
+	val = v
+	return
+}
+
+// GetLast will attempt to get the last entry associated with a set of given filters
+// Note: Will return ErrEntryNotFound if no match is found
+func (c *CollectionTransaction[T]) GetLast(o *IteratingOpts) (val T, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	v := c.newValue()
+	if err = c.txn.GetLast(v, o); err != nil {
+		return
+	}
+
+	val = v
+	return
+}
+
+// Cursor will return a typed iterating cursor
+func (c *CollectionTransaction[T]) Cursor(fs ...Filter) (cur *TypedCursor[T], err error) {
+	var cc Cursor
+	if cc, err = c.txn.Cursor(fs...); err != nil {
+		return
+	}
+
+	cur = newTypedCursor[T](cc)
+	return
+}
+
+// ForEach will iterate through entries
+func (c *CollectionTransaction[T]) ForEach(fn func(T) error, o *IteratingOpts) (err error) {
+	return c.txn.ForEach(func(entryID string, val Value) (err error) {
+		return fn(val.(T))
+	}, o)
+}
+
+// ForEachID will iterate through entry IDs
+func (c *CollectionTransaction[T]) ForEachID(fn ForEachIDFn, o *IteratingOpts) (err error) {
+	return c.txn.ForEachID(fn, o)
+}
+
+// Put will place an entry at a given entry ID
+// Note: This will not check to see if the entry exists beforehand. If this functionality
+// is needed, look into using the Edit method
+func (c *CollectionTransaction[T]) Put(entryID string, val T) (err error) {
+	return c.txn.Put(entryID, val)
+}
+
+// Edit will attempt to edit an entry by ID
+func (c *CollectionTransaction[T]) Edit(entryID string, val T) (err error) {
+	return c.txn.Edit(entryID, val)
+}
+
+// Remove will remove a relationship ID and its related relationship IDs
+func (c *CollectionTransaction[T]) Remove(entryID string) (err error) {
+	return c.txn.Remove(entryID)
+}
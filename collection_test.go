@@ -0,0 +1,94 @@
+package mojura
+
+import (
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func TestCollection(t *testing.T) {
+	var (
+		c   *Collection[*testStruct]
+		err error
+	)
+
+	if c, err = NewCollection[*testStruct]("test", testDir, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c.Mojura())
+
+	a := newTestStruct("user_1", "contact_1", "group_1", "a")
+	b := newTestStruct("user_2", "contact_1", "group_1", "b")
+
+	var entryID string
+	if entryID, err = c.New(a); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = c.New(b); err != nil {
+		t.Fatal(err)
+	}
+
+	var got *testStruct
+	if got, err = c.Get(entryID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheck(a, got); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []*testStruct
+	opts := NewFilteringOpts(filters.Match("contacts", "contact_1"))
+	opts.Limit = 1
+	if entries, _, err = c.GetFiltered(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 1, len(entries))
+	}
+
+	if err = testCheck(a, entries[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	if err = c.ForEach(func(val *testStruct) (err error) {
+		values = append(values, val.Value)
+		return
+	}, NewIteratingOpts(filters.Match("users", "user_2"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if !isSliceMatch(values, []string{"b"}) {
+		t.Fatalf("invalid values, expected %v and received %v", []string{"b"}, values)
+	}
+
+	if err = c.Cursor(func(cur *TypedCursor[*testStruct]) (err error) {
+		var val *testStruct
+		if val, err = cur.Last(); err != nil {
+			return
+		}
+
+		return testCheck(b, val)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewCollectionFromMojura_invalid_type(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if _, err = NewCollectionFromMojura[*Entry](m); err != ErrInvalidType {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidType, err)
+	}
+}
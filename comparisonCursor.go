@@ -25,6 +25,21 @@ func newComparisonCursor(txn *Transaction, f *filters.ComparisonFilter) (cur *co
 	return
 }
 
+func newPrefixCursor(txn *Transaction, f *filters.PrefixFilter) (cur *comparisonCursor, err error) {
+	var c comparisonCursor
+	if c.parent, err = txn.getRelationshipBucket([]byte(f.RelationshipKey)); err != nil {
+		return
+	}
+
+	c.txn = txn
+	c.bktCur = c.parent.Cursor()
+	// Relationship IDs are bound by the prefix, so every bucket within the bounds is a match
+	c.isMatch = matchAllComparisonFn
+	c.prefix = []byte(f.Prefix)
+	cur = &c
+	return
+}
+
 type comparisonCursor struct {
 	txn *Transaction
 
@@ -34,12 +49,142 @@ type comparisonCursor struct {
 
 	rangeStart            []byte
 	rangeEnd              []byte
+	prefix                []byte
 	currentRelationshipID []byte
 
 	isMatch filters.ComparisonFn
+
+	// Set when entries are only yielded once (see enableDistinct)
+	distinct *distinctTracker
+}
+
+// enableDistinct will ensure entries with multiple matching relationship IDs are only yielded once
+func (c *comparisonCursor) enableDistinct(reverse bool) {
+	c.distinct = &distinctTracker{
+		parent:          c.parent,
+		isMatch:         c.isBucketMatch,
+		isBeyond:        c.isBucketBeyond,
+		positionReverse: reverse,
+	}
+}
+
+// isBucketMatch will return whether or not the bucket of a relationship ID is iterated
+func (c *comparisonCursor) isBucketMatch(relationshipID []byte) (ok bool, err error) {
+	switch {
+	case len(c.prefix) > 0 && !bytes.HasPrefix(relationshipID, c.prefix):
+		return false, nil
+	case len(c.rangeStart) > 0 && bytes.Compare(relationshipID, c.rangeStart) == -1:
+		return false, nil
+	case len(c.rangeEnd) > 0 && bytes.Compare(relationshipID, c.rangeEnd) == 1:
+		return false, nil
+	}
+
+	if ok, err = c.isMatch(string(relationshipID)); err == filters.ErrStopAfter || err == filters.ErrStopBefore {
+		return false, nil
+	}
+
+	return
+}
+
+// compare will determine if a relationship ID matches while iterating in the provided direction
+// Note: Break is returned when the comparison signals that no further relationship IDs will match
+func (c *comparisonCursor) compare(relationshipID []byte, reverse bool) (isMatch bool, err error) {
+	// This []byte -> string conversion should be non-existent after the compier pass
+	isMatch, err = c.isMatch(string(relationshipID))
+	switch {
+	case err == filters.ErrStopAfter && !reverse, err == filters.ErrStopBefore && reverse:
+		return false, Break
+	case err == filters.ErrStopAfter, err == filters.ErrStopBefore:
+		// Relationship IDs in the direction of iteration can still match
+		return false, nil
+	}
+
+	return
+}
+
+// isBucketBeyond will return whether or not no further buckets are iterated past the relationship ID in the provided direction
+func (c *comparisonCursor) isBucketBeyond(relationshipID []byte, reverse bool) (ok bool) {
+	if reverse {
+		return (len(c.rangeStart) > 0 && bytes.Compare(relationshipID, c.rangeStart) == -1) ||
+			(len(c.prefix) > 0 && bytes.Compare(relationshipID, c.prefix) == -1)
+	}
+
+	return (len(c.rangeEnd) > 0 && bytes.Compare(relationshipID, c.rangeEnd) == 1) ||
+		(len(c.prefix) > 0 && !bytes.HasPrefix(relationshipID, c.prefix) && bytes.Compare(relationshipID, c.prefix) == 1)
+}
+
+// nextDistinct will iterate forward until an entry which has not been yielded is found
+func (c *comparisonCursor) nextDistinct(entryID []byte) (distinctEntryID []byte, err error) {
+	if c.distinct == nil {
+		return entryID, nil
+	}
+
+	var ok bool
+	for {
+		if ok, err = c.distinct.isDistinct(c.currentRelationshipID, entryID, false); err != nil || ok {
+			return entryID, err
+		}
+
+		if entryID, err = c.next(); err != nil {
+			return
+		}
+
+		if entryID, err = c.nextUntilMatch(entryID); err != nil {
+			return
+		}
+	}
+}
+
+// prevDistinct will iterate in reverse until an entry which has not been yielded is found
+func (c *comparisonCursor) prevDistinct(entryID []byte) (distinctEntryID []byte, err error) {
+	if c.distinct == nil {
+		return entryID, nil
+	}
+
+	var ok bool
+	for {
+		if ok, err = c.distinct.isDistinct(c.currentRelationshipID, entryID, true); err != nil || ok {
+			return entryID, err
+		}
+
+		if entryID, err = c.prev(); err != nil {
+			return
+		}
+
+		if entryID, err = c.prevUntilMatch(entryID); err != nil {
+			return
+		}
+	}
+}
+
+// resetDistinct will begin a new distinct walk from the current relationship ID
+// Note: When fromStart is true, the walk began at the first bucket in the direction of iteration
+func (c *comparisonCursor) resetDistinct(fromStart, reverse bool) {
+	if c.distinct == nil {
+		return
+	}
+
+	boundary := c.currentRelationshipID
+	if fromStart {
+		boundary = nil
+	}
+
+	c.distinct.reset(boundary, reverse)
+}
+
+func (c *comparisonCursor) prefixCheck() (ok bool) {
+	if len(c.prefix) == 0 {
+		return true
+	}
+
+	return bytes.HasPrefix(c.currentRelationshipID, c.prefix)
 }
 
 func (c *comparisonCursor) rangeStartCheck() (ok bool) {
+	if !c.prefixCheck() {
+		return false
+	}
+
 	if len(c.rangeStart) == 0 {
 		return true
 	}
@@ -48,6 +193,10 @@ func (c *comparisonCursor) rangeStartCheck() (ok bool) {
 }
 
 func (c *comparisonCursor) rangeEndCheck() (ok bool) {
+	if !c.prefixCheck() {
+		return false
+	}
+
 	if len(c.rangeEnd) == 0 {
 		return true
 	}
@@ -123,8 +272,7 @@ func (c *comparisonCursor) setNextCursor() (err error) {
 func (c *comparisonCursor) nextUntilMatch(entryID []byte) (matchingEntryID []byte, err error) {
 	var isMatch bool
 	for err == nil {
-		// This []byte -> string conversion should be non-existent after the compier pass
-		isMatch, err = c.isMatch(string(c.currentRelationshipID))
+		isMatch, err = c.compare(c.currentRelationshipID, false)
 		switch {
 		case err != nil:
 			return
@@ -132,6 +280,8 @@ func (c *comparisonCursor) nextUntilMatch(entryID []byte) (matchingEntryID []byt
 			matchingEntryID = entryID
 			return
 		default:
+			// Relationship ID does not match, skip the remaining entries of the bucket
+			c.cur.Last()
 			entryID, err = c.next()
 		}
 	}
@@ -203,8 +353,7 @@ func (c *comparisonCursor) setPrevCursor() (err error) {
 func (c *comparisonCursor) prevUntilMatch(entryID []byte) (matchingEntryID []byte, err error) {
 	var isMatch bool
 	for err == nil {
-		// This []byte -> string conversion should be non-existent after the compier pass
-		isMatch, err = c.isMatch(string(c.currentRelationshipID))
+		isMatch, err = c.compare(c.currentRelationshipID, true)
 		switch {
 		case err != nil:
 			return
@@ -212,6 +361,8 @@ func (c *comparisonCursor) prevUntilMatch(entryID []byte) (matchingEntryID []byt
 			matchingEntryID = entryID
 			return
 		default:
+			// Relationship ID does not match, skip the remaining entries of the bucket
+			c.cur.First()
 			entryID, err = c.prev()
 		}
 	}
@@ -225,6 +376,10 @@ func (c *comparisonCursor) teardown() {
 }
 
 func (c *comparisonCursor) firstBktKey() (bktKey []byte, err error) {
+	if bktKey = c.prefix; len(bktKey) > 0 {
+		return
+	}
+
 	if bktKey = c.rangeStart; len(bktKey) > 0 {
 		return
 	}
@@ -247,6 +402,11 @@ func (c *comparisonCursor) first() (entryID []byte, err error) {
 		return
 	}
 
+	if !c.prefixCheck() {
+		err = Break
+		return
+	}
+
 	if entryID, _ = c.cur.First(); entryID == nil {
 		err = Break
 		return
@@ -255,7 +415,31 @@ func (c *comparisonCursor) first() (entryID []byte, err error) {
 	return
 }
 
+func (c *comparisonCursor) lastPrefixBktKey() (bktKey []byte, err error) {
+	// Seek the first bucket which follows all of the prefixed buckets
+	if upperBound := getPrefixUpperBound(c.prefix); upperBound != nil {
+		if bktKey, _ = c.bktCur.Seek(upperBound); bktKey != nil {
+			bktKey, _ = c.bktCur.Prev()
+		} else {
+			bktKey, _ = c.bktCur.Last()
+		}
+	} else {
+		bktKey, _ = c.bktCur.Last()
+	}
+
+	if bktKey == nil {
+		err = Break
+		return
+	}
+
+	return
+}
+
 func (c *comparisonCursor) lastBktKey() (bktKey []byte, err error) {
+	if len(c.prefix) > 0 {
+		return c.lastPrefixBktKey()
+	}
+
 	if bktKey = c.rangeEnd; len(bktKey) > 0 {
 		return
 	}
@@ -278,6 +462,11 @@ func (c *comparisonCursor) last() (entryID []byte, err error) {
 		return
 	}
 
+	if !c.prefixCheck() {
+		err = Break
+		return
+	}
+
 	if entryID, _ = c.cur.Last(); entryID == nil {
 		err = Break
 		return
@@ -370,13 +559,23 @@ func (c *comparisonCursor) SeekForward(relationshipID, seekID []byte) (entryID [
 		return
 	}
 
+	if !c.prefixCheck() {
+		err = Break
+		return
+	}
+
 	entryID, _ = c.cur.Seek([]byte(seekID))
 	if entryID == nil {
 		err = Break
 		return
 	}
 
-	return c.nextUntilMatch(entryID)
+	if entryID, err = c.nextUntilMatch(entryID); err != nil {
+		return
+	}
+
+	c.resetDistinct(false, false)
+	return c.nextDistinct(entryID)
 }
 
 // SeekReverse will seek the provided ID in a reverse direction
@@ -389,13 +588,23 @@ func (c *comparisonCursor) SeekReverse(relationshipID, seekID []byte) (entryID [
 		return
 	}
 
+	if !c.prefixCheck() {
+		err = Break
+		return
+	}
+
 	entryID, _ = c.cur.Seek([]byte(seekID))
 	if entryID == nil {
 		err = Break
 		return
 	}
 
-	return c.prevUntilMatch(entryID)
+	if entryID, err = c.prevUntilMatch(entryID); err != nil {
+		return
+	}
+
+	c.resetDistinct(false, true)
+	return c.prevDistinct(entryID)
 }
 
 // First will return the first entry
@@ -408,8 +617,12 @@ func (c *comparisonCursor) First() (entryID []byte, err error) {
 		return
 	}
 
-	return c.nextUntilMatch(entryID)
+	if entryID, err = c.nextUntilMatch(entryID); err != nil {
+		return
+	}
 
+	c.resetDistinct(true, false)
+	return c.nextDistinct(entryID)
 }
 
 // Next will return the next entry
@@ -422,7 +635,11 @@ func (c *comparisonCursor) Next() (entryID []byte, err error) {
 		return
 	}
 
-	return c.nextUntilMatch(entryID)
+	if entryID, err = c.nextUntilMatch(entryID); err != nil {
+		return
+	}
+
+	return c.nextDistinct(entryID)
 }
 
 // Prev will return the previous entry
@@ -435,7 +652,11 @@ func (c *comparisonCursor) Prev() (entryID []byte, err error) {
 		return
 	}
 
-	return c.prevUntilMatch(entryID)
+	if entryID, err = c.prevUntilMatch(entryID); err != nil {
+		return
+	}
+
+	return c.prevDistinct(entryID)
 }
 
 // Last will return the last entry
@@ -448,7 +669,12 @@ func (c *comparisonCursor) Last() (entryID []byte, err error) {
 		return
 	}
 
-	return c.prevUntilMatch(entryID)
+	if entryID, err = c.prevUntilMatch(entryID); err != nil {
+		return
+	}
+
+	c.resetDistinct(true, true)
+	return c.prevDistinct(entryID)
 }
 
 // HasForward will determine if an entry exists in a forward direction
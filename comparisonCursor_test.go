@@ -826,6 +826,64 @@ func Test_comparisonCursor_HasReverse(t *testing.T) {
 	testComparisonCursorHas(t, fn)
 }
 
+func Test_comparisonCursor_Prefix(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("org:1", "contact_0", "group_0", "0"),
+		newTestStruct("org:1:team:9", "contact_1", "group_1", "1"),
+		newTestStruct("org:12", "contact_0", "group_1", "2"),
+		newTestStruct("org:1:team:2", "contact_1", "group_0", "3"),
+		newTestStruct("org:2:team:1", "contact_0", "group_1", "4"),
+		newTestStruct("org:0", "contact_0", "group_1", "5"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters:  []Filter{filters.Prefix("users", "org:1:")},
+			expected: []string{"00000003", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Prefix("users", "org:1")},
+			expected: []string{"00000000", "00000002", "00000003", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Prefix("users", "org:1")},
+			reverse:  true,
+			expected: []string{"00000001", "00000003", "00000002", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.Match("contacts", "contact_0"), filters.Prefix("users", "org:1")},
+			expected: []string{"00000000", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.Prefix("users", "org:3")},
+			expected: []string{},
+		},
+		{
+			filters:  []Filter{filters.Prefix("users", "org:3")},
+			reverse:  true,
+			expected: []string{},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
 func testComparisonCursorHas(t *testing.T, fn func(c *comparisonCursor, entryID []byte) (value bool, err error)) {
 	type expected struct {
 		value bool
@@ -964,3 +1022,69 @@ func testComparisonCursor(t *testing.T, fn func(*Transaction) error) {
 		t.Fatal(err)
 	}
 }
+
+func Test_comparisonCursor_ErrStop(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 10; i++ {
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "", fmt.Sprintf("tag_%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var calls int
+	upTo := func(relationshipID string) (ok bool, err error) {
+		calls++
+		if ok = relationshipID <= "tag_2"; !ok {
+			err = filters.ErrStopAfter
+		}
+
+		return
+	}
+
+	from := func(relationshipID string) (ok bool, err error) {
+		calls++
+		if ok = relationshipID >= "tag_7"; !ok {
+			err = filters.ErrStopBefore
+		}
+
+		return
+	}
+
+	type testcase struct {
+		fn       filters.ComparisonFn
+		reverse  bool
+		expected []string
+		maxCalls int
+	}
+
+	tcs := []testcase{
+		{fn: upTo, expected: []string{"00000000", "00000001", "00000002"}, maxCalls: 4},
+		{fn: upTo, reverse: true, expected: []string{"00000002", "00000001", "00000000"}, maxCalls: 10},
+		{fn: from, expected: []string{"00000007", "00000008", "00000009"}, maxCalls: 10},
+		{fn: from, reverse: true, expected: []string{"00000009", "00000008", "00000007"}, maxCalls: 4},
+	}
+
+	for i, tc := range tcs {
+		calls = 0
+		testFilteredCases(t, m, []testFilteredCase{
+			{
+				filters:  []Filter{filters.Comparison("tags", tc.fn)},
+				reverse:  tc.reverse,
+				expected: tc.expected,
+			},
+		})
+
+		if calls > tc.maxCalls {
+			t.Fatalf("test case #%d: invalid number of comparisons, expected at most %d and received %d", i, tc.maxCalls, calls)
+		}
+	}
+}
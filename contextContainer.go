@@ -2,7 +2,8 @@ package mojura
 
 import (
 	"context"
-	"sync"
+
+	"github.com/gdbu/atoms"
 )
 
 func newContextContainer(ctx context.Context) *contextContainer {
@@ -11,104 +12,53 @@ func newContextContainer(ctx context.Context) *contextContainer {
 	return &c
 }
 
+// contextContainer manages the cancellation state of a transaction. Cancellation is cooperative,
+// once the context has ended (or the container has been closed), the container is marked as
+// unusable and every following call to isDone will return ErrContextCancelled
 type contextContainer struct {
-	mux sync.RWMutex
-
 	ctx    context.Context
-	cancel chan struct{}
-	done   chan struct{}
+	parent *contextContainer
 
-	err    error
-	closed bool
+	// Cancelled state
+	cancelled atoms.Bool
 }
 
 func (c *contextContainer) isDone() (err error) {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	// Check to see if context is done
-	if !isDone(c.ctx) {
-		// Context is not done, return
-		return
-	}
-
-	// Attempt to get error from context
-	if err = c.ctx.Err(); err != nil {
-		// Context has an error, return
-		return
-	}
+	switch {
+	case c.cancelled.Get():
+	case c.parent != nil && c.parent.isDone() != nil:
+		// Parent container has been cancelled, mark as unusable
+		c.cancelled.Set(true)
+	case isDone(c.ctx):
+		// Context is done, mark as unusable
+		c.cancelled.Set(true)
 
-	// Set error as default cancelled error
-	err = ErrContextCancelled
-	return
-}
-
-func (c *contextContainer) cancelCurrent() {
-	select {
-	case c.cancel <- struct{}{}:
 	default:
-	}
-}
-
-func (c *contextContainer) update(ctx context.Context) (ok bool) {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	if c.closed {
+		// Context is still active, return
 		return
 	}
 
-	// Set context
-	c.ctx = ctx
-	// Set true
-	ok = true
-	// Cancel current context waiter if exists
-	c.cancelCurrent()
-	// Start new context waiter
-	go c.waitForClose()
-	return
+	return ErrContextCancelled
 }
 
-func (c *contextContainer) getContext() (ctx context.Context) {
-	c.mux.RLock()
-	c.mux.RUnlock()
-	ctx = c.ctx
+// withContext will return a child container which uses the provided context. The child container
+// will be marked as unusable when the parent container is cancelled or closed
+func (c *contextContainer) withContext(ctx context.Context) (child *contextContainer) {
+	child = newContextContainer(ctx)
+	child.parent = c
 	return
 }
 
-func (c *contextContainer) waitForClose() {
-	ctx := c.getContext()
-
-	select {
-	case <-ctx.Done():
-		c.setError(ctx.Err())
-		close(c.done)
-	case <-c.cancel:
+// Err will return the context error if the context has ended
+func (c *contextContainer) Err() (err error) {
+	if !isDone(c.ctx) {
+		return
 	}
 
-	return
-}
-
-func (c *contextContainer) setError(err error) {
-	c.mux.Lock()
-	c.mux.Unlock()
-	c.err = err
-}
-
-func (c *contextContainer) Done() <-chan struct{} {
-	return c.done
-}
-
-func (c *contextContainer) Err() (err error) {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	err = c.err
-	return
+	return c.ctx.Err()
 }
 
+// Close will mark the container as unusable
 func (c *contextContainer) Close() {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	c.cancelCurrent()
-	close(c.done)
-	close(c.cancel)
-	c.closed = true
+	c.cancelled.Set(true)
 }
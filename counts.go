@@ -0,0 +1,79 @@
+package mojura
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/mojura/backend"
+)
+
+var (
+	countsBktKey    = []byte("counts")
+	statsBktKey     = []byte("stats")
+	entriesStatKey  = []byte("entries")
+	missingStatsKey = []byte("missing:")
+	// Stats key prefix for the normalization each relationship was indexed with
+	normalizationStatsKey = []byte("normalization:")
+)
+
+func getNormalizationStatKey(relationship []byte) (key []byte) {
+	key = make([]byte, 0, len(normalizationStatsKey)+len(relationship))
+	key = append(key, normalizationStatsKey...)
+	key = append(key, relationship...)
+	return
+}
+
+func getMissingStatKey(relationship []byte) (key []byte) {
+	key = make([]byte, 0, len(missingStatsKey)+len(relationship))
+	key = append(key, missingStatsKey...)
+	key = append(key, relationship...)
+	return
+}
+
+// getCount will return the count stored at the provided key
+// Note: Counts are deleted when they reach zero, so a missing key represents a count of zero
+func getCount(bkt backend.Bucket, key []byte) (count int64) {
+	return parseCount(bkt.Get(key))
+}
+
+// parseCount will parse a stored count
+func parseCount(bs []byte) (count int64) {
+	if len(bs) != 8 {
+		return
+	}
+
+	return int64(binary.BigEndian.Uint64(bs))
+}
+
+// putCount will store a count at the provided key
+func putCount(bkt backend.Bucket, key []byte, count int64) (err error) {
+	if count <= 0 {
+		return bkt.Delete(key)
+	}
+
+	bs := make([]byte, 8)
+	binary.BigEndian.PutUint64(bs, uint64(count))
+	return bkt.Put(key, bs)
+}
+
+// addCount will add the delta to the count stored at the provided key
+func addCount(bkt backend.Bucket, key []byte, delta int64) (err error) {
+	return putCount(bkt, key, getCount(bkt, key)+delta)
+}
+
+// countKeys will return the number of keys within a bucket
+func countKeys(bkt backend.Bucket) (count int64) {
+	cur := bkt.Cursor()
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		count++
+	}
+
+	return
+}
+
+// hasKey will return whether or not a key exists within a bucket
+func hasKey(bkt backend.Bucket, key []byte) (ok bool) {
+	// Get the first key matching key (will get next key if key does not exist)
+	firstKey, _ := bkt.Cursor().Seek(key)
+	return bytes.Equal(key, firstKey)
+}
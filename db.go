@@ -0,0 +1,296 @@
+package mojura
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/gdbu/actions"
+	"github.com/gdbu/atoms"
+	"github.com/gdbu/indexer"
+	"github.com/hatchify/errors"
+
+	"github.com/mojura/backend"
+)
+
+const (
+	// ErrCollectionNotFound is returned when a collection is not available for the given name
+	ErrCollectionNotFound = errors.Error("collection was not found")
+	// ErrCollectionExists is returned when a collection has already been created for the given name
+	ErrCollectionExists = errors.Error("collection already exists")
+	// ErrEmptyCollectionName is returned when a collection name is empty
+	ErrEmptyCollectionName = errors.Error("invalid collection name, cannot be empty")
+)
+
+// NewDB will return a new instance of DB
+func NewDB(name, dir string) (d *DB, err error) {
+	return NewDBWithOpts(name, dir, defaultOpts)
+}
+
+// NewDBWithOpts will return a new instance of DB
+func NewDBWithOpts(name, dir string, opts Opts) (dp *DB, err error) {
+	var d DB
+	if err = opts.Validate(); err != nil {
+		return
+	}
+
+	d.name = name
+	d.dir = dir
+	d.opts = opts
+	d.logsDir = path.Join(dir, "logs")
+	d.collections = make(map[string]*Mojura)
+
+	if err = os.MkdirAll(d.logsDir, 0744); err != nil {
+		return
+	}
+
+	filename := path.Join(dir, name+".bdb")
+	if d.db, err = opts.Initializer.New(filename); err != nil {
+		err = fmt.Errorf("error opening db for %s (%s): %v", name, dir, err)
+		return
+	}
+
+	if d.a, err = newActions(d.logsDir, name, d.handleLogRotation); err != nil {
+		d.db.Close()
+		return
+	}
+
+	dp = &d
+	return
+}
+
+// DB hosts many named collections within a single backend. Each collection is stored within
+// its own bucket namespace, which allows for atomic transactions across collections
+type DB struct {
+	mux sync.RWMutex
+
+	db backend.Backend
+	a  *actions.Actions
+
+	opts    Opts
+	name    string
+	dir     string
+	logsDir string
+
+	collections map[string]*Mojura
+	// Transactions which are in-flight
+	txns transactionTracker
+
+	// Closed state
+	closed atoms.Bool
+}
+
+func (d *DB) handleLogRotation(filename string) {
+	archiveLog(d.logsDir, filename)
+}
+
+func (d *DB) getCollection(name string) (m *Mojura, err error) {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+
+	var ok bool
+	if m, ok = d.collections[name]; !ok {
+		err = ErrCollectionNotFound
+		return
+	}
+
+	return
+}
+
+func (d *DB) getCollections() (ms []*Mojura) {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	ms = make([]*Mojura, 0, len(d.collections))
+	for _, m := range d.collections {
+		ms = append(ms, m)
+	}
+
+	return
+}
+
+func (d *DB) flushIndexes() {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	for _, m := range d.collections {
+		m.idx.Flush()
+	}
+}
+
+func (d *DB) runTransaction(ctx context.Context, txn backend.Transaction, atxn *actions.Transaction, fn func(*DBTransaction) error) (err error) {
+	t := newDBTransaction(ctx, d, txn, atxn)
+	// Ensure the transaction (and all of its collection transactions) are marked as unusable once the function has returned
+	defer t.teardown()
+	// Always ensure indexes have been flushed
+	defer d.flushIndexes()
+
+	// Call function within the current goroutine. This ensures the backend transaction
+	// will not be completed until the function has returned
+	fnErr := fn(&t)
+
+	// Context errors take precedence, attempt to set error from Context
+	if err = t.cc.Err(); err != nil {
+		return
+	}
+
+	return fnErr
+}
+
+// NewCollection will create a new collection with the given entry type and relationships
+func (d *DB) NewCollection(name string, example Value, relationships ...string) (mp *Mojura, err error) {
+	if len(name) == 0 {
+		err = ErrEmptyCollectionName
+		return
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	// Closed state is checked while locked, collections created before the DB was closed will be closed by Close
+	if d.closed.Get() {
+		err = errors.ErrIsClosed
+		return
+	}
+
+	if _, ok := d.collections[name]; ok {
+		err = ErrCollectionExists
+		return
+	}
+
+	var m *Mojura
+	if m, err = newMojura(example, d.opts, relationships); err != nil {
+		return
+	}
+
+	m.db = d.db
+	m.a = d.a
+	m.parent = d
+	m.logsDir = d.logsDir
+	m.namespace = []byte(name)
+	m.entriesLogKey = []byte(name + "." + string(entriesBktKey))
+
+	indexFilename := path.Join(d.dir, d.name+"."+name+".idb")
+	if m.idx, err = indexer.New(indexFilename); err != nil {
+		err = fmt.Errorf("error opening index db for %s (%s): %v", name, d.dir, err)
+		return
+	}
+
+	if err = m.initBuckets(relationships); err != nil {
+		m.idx.Close()
+		return
+	}
+
+	d.collections[name] = m
+	mp = m
+	return
+}
+
+// Collection will return the collection for the given name
+func (d *DB) Collection(name string) (m *Mojura, err error) {
+	if d.closed.Get() {
+		err = errors.ErrIsClosed
+		return
+	}
+
+	return d.getCollection(name)
+}
+
+// Transaction will initialize a transaction which spans all collections
+func (d *DB) Transaction(ctx context.Context, fn func(*DBTransaction) error) (err error) {
+	if !d.txns.begin() {
+		return errors.ErrIsClosed
+	}
+	defer d.txns.end()
+
+	err = d.db.Transaction(func(txn backend.Transaction) (err error) {
+		return d.a.Transaction(func(atxn *actions.Transaction) (err error) {
+			return d.runTransaction(ctx, txn, atxn, fn)
+		})
+	})
+
+	return
+}
+
+// ReadTransaction will initialize a read-only transaction which spans all collections
+func (d *DB) ReadTransaction(ctx context.Context, fn func(*DBTransaction) error) (err error) {
+	if !d.txns.begin() {
+		return errors.ErrIsClosed
+	}
+	defer d.txns.end()
+
+	err = d.db.ReadTransaction(func(txn backend.Transaction) (err error) {
+		return d.runTransaction(ctx, txn, nil, fn)
+	})
+
+	return
+}
+
+// Close will close the DB and all of its collections
+// Note: Pending batch calls for each collection will be flushed and in-flight transactions will
+// complete before the underlying stores are closed
+func (d *DB) Close() (err error) {
+	return d.CloseCtx(context.Background())
+}
+
+// CloseCtx will close the DB and all of its collections. New calls will be rejected with
+// ErrIsClosed while any in-flight transactions complete and the collections are closed.
+// If the context ends before the drain completes, the context error is returned and the
+// underlying stores will be closed once the drain completes
+func (d *DB) CloseCtx(ctx context.Context) (err error) {
+	if !d.closed.Set(true) {
+		return errors.ErrIsClosed
+	}
+
+	// Stop accepting new transactions
+	d.txns.close()
+
+	var errs errors.ErrorList
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		// Collections share the backend of the DB, they are closed once the DB transactions have completed
+		d.txns.wait()
+		for _, m := range d.getCollections() {
+			if err := m.Close(); err != nil && err != errors.ErrIsClosed {
+				errs.Push(err)
+			}
+		}
+	}()
+
+	select {
+	case <-drained:
+		errs.Push(d.closeStores())
+		return errs.Err()
+	case <-ctx.Done():
+		// Context ended before the drain completed, close the stores in the background
+		go func() {
+			<-drained
+			d.closeStores()
+		}()
+
+		return ctx.Err()
+	}
+}
+
+func (d *DB) closeStores() (err error) {
+	var errs errors.ErrorList
+	errs.Push(d.db.Close())
+	errs.Push(d.a.Close())
+	return errs.Err()
+}
+
+// NewDBCollection will create a new typed collection within the provided DB
+func NewDBCollection[T Value](d *DB, name string, relationships ...string) (c *Collection[T], err error) {
+	var example T
+	if example, err = newCollectionExample[T](); err != nil {
+		return
+	}
+
+	var m *Mojura
+	if m, err = d.NewCollection(name, example, relationships...); err != nil {
+		return
+	}
+
+	return NewCollectionFromMojura[T](m)
+}
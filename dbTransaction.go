@@ -0,0 +1,59 @@
+package mojura
+
+import (
+	"context"
+
+	"github.com/gdbu/actions"
+	"github.com/mojura/backend"
+)
+
+func newDBTransaction(ctx context.Context, d *DB, txn backend.Transaction, atxn *actions.Transaction) (t DBTransaction) {
+	t.d = d
+	t.cc = newContextContainer(ctx)
+	t.txn = txn
+	t.atxn = atxn
+	t.txns = make(map[string]*Transaction)
+	return
+}
+
+// DBTransaction manages a transaction which spans all collections of a DB
+type DBTransaction struct {
+	d *DB
+
+	cc *contextContainer
+
+	txn  backend.Transaction
+	atxn *actions.Transaction
+
+	txns map[string]*Transaction
+}
+
+func (t *DBTransaction) teardown() {
+	// Mark the transaction (and all of its collection transactions) as unusable
+	t.cc.Close()
+}
+
+// Collection will return the transaction for the given collection name
+func (t *DBTransaction) Collection(name string) (txn *Transaction, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	var ok bool
+	if txn, ok = t.txns[name]; ok {
+		return
+	}
+
+	var m *Mojura
+	if m, err = t.d.getCollection(name); err != nil {
+		return
+	}
+
+	// Collection transactions use a child context container, this ensures they
+	// are marked as unusable when the DB transaction is torn down
+	cc := t.cc.withContext(t.cc.ctx)
+	ct := newTransaction(cc, m, t.txn, t.atxn)
+	txn = &ct
+	t.txns[name] = txn
+	return
+}
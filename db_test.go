@@ -0,0 +1,483 @@
+package mojura
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hatchify/errors"
+	"github.com/mojura/mojura/filters"
+)
+
+func TestDB_Transaction(t *testing.T) {
+	var (
+		d   *DB
+		err error
+	)
+
+	if d, err = testInitDB(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardownDB(d)
+
+	var users, profiles *Mojura
+	if users, err = d.NewCollection("users", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if profiles, err = d.NewCollection("profiles", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = d.NewCollection("users", &testStruct{}, "users", "contacts", "groups", "tags"); err != ErrCollectionExists {
+		t.Fatalf("invalid error, expected %v and received %v", ErrCollectionExists, err)
+	}
+
+	user := newTestStruct("user_1", "contact_1", "group_1", "user")
+	profile := newTestStruct("user_1", "contact_1", "group_1", "profile")
+
+	if err = d.Transaction(context.Background(), func(txn *DBTransaction) (err error) {
+		var utxn, ptxn *Transaction
+		if utxn, err = txn.Collection("users"); err != nil {
+			return
+		}
+
+		if ptxn, err = txn.Collection("profiles"); err != nil {
+			return
+		}
+
+		if _, err = utxn.New(user); err != nil {
+			return
+		}
+
+		_, err = ptxn.New(profile)
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got testStruct
+	if err = users.Get("00000000", &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheck(user, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = profiles.Get("00000000", &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheck(profile, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	errRollback := errors.Error("rollback")
+	if err = d.Transaction(context.Background(), func(txn *DBTransaction) (err error) {
+		var utxn, ptxn *Transaction
+		if utxn, err = txn.Collection("users"); err != nil {
+			return
+		}
+
+		if ptxn, err = txn.Collection("profiles"); err != nil {
+			return
+		}
+
+		if err = utxn.Remove("00000000"); err != nil {
+			return
+		}
+
+		if _, err = ptxn.New(profile); err != nil {
+			return
+		}
+
+		return errRollback
+	}); err != errRollback {
+		t.Fatalf("invalid error, expected %v and received %v", errRollback, err)
+	}
+
+	var entries []*testStruct
+	if _, err = users.GetFiltered(&entries, NewFilteringOpts(filters.Match("users", "user_1"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("invalid number of users, expected %d and received %d", 1, len(entries))
+	}
+
+	entries = entries[:0]
+	if _, err = profiles.GetFiltered(&entries, NewFilteringOpts(filters.Match("users", "user_1"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("invalid number of profiles, expected %d and received %d", 1, len(entries))
+	}
+
+	if err = d.ReadTransaction(context.Background(), func(txn *DBTransaction) (err error) {
+		_, err = txn.Collection("invoices")
+		return
+	}); err != ErrCollectionNotFound {
+		t.Fatalf("invalid error, expected %v and received %v", ErrCollectionNotFound, err)
+	}
+}
+
+func TestDB_Close_transactions(t *testing.T) {
+	var (
+		d   *DB
+		err error
+	)
+
+	if d, err = testInitDB(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardownDB(nil)
+
+	if _, err = d.NewCollection("users", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	txnErr := make(chan error, 1)
+	go func() {
+		txnErr <- d.Transaction(context.Background(), func(txn *DBTransaction) (err error) {
+			close(started)
+			<-release
+
+			var utxn *Transaction
+			if utxn, err = txn.Collection("users"); err != nil {
+				return
+			}
+
+			_, err = utxn.New(newTestStruct("user_1", "contact_1", "group_1", "user"))
+			return
+		})
+	}()
+
+	<-started
+
+	closeErr := make(chan error, 1)
+	go func() {
+		closeErr <- d.Close()
+	}()
+
+	// Wait for the close to begin
+	for !d.closed.Get() {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err = d.ReadTransaction(context.Background(), func(txn *DBTransaction) (err error) {
+		return
+	}); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	select {
+	case err = <-closeErr:
+		t.Fatalf("invalid close state, expected close to wait for in-flight transaction and received %v", err)
+	default:
+	}
+
+	close(release)
+
+	select {
+	case err = <-txnErr:
+	case <-time.After(time.Second * 5):
+		t.Fatal("transaction did not return while the DB was closing")
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = <-closeErr; err != nil {
+		t.Fatal(err)
+	}
+
+	if d, err = testInitDB(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	var users *Mojura
+	if users, err = d.NewCollection("users", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got testStruct
+	if err = users.Get("00000000", &got); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMojura_SetReference(t *testing.T) {
+	type testcase struct {
+		policy     ReferencePolicy
+		removeErr  error
+		postExists bool
+		postUserID string
+	}
+
+	tcs := []testcase{
+		{policy: ReferenceRestrict, removeErr: ErrReferenceRestricted, postExists: true, postUserID: "00000000"},
+		{policy: ReferenceCascade, postExists: false},
+		{policy: ReferenceUnset, postExists: true, postUserID: ""},
+	}
+
+	for _, tc := range tcs {
+		if err := testSetReference(tc.policy, tc.removeErr, tc.postExists, tc.postUserID); err != nil {
+			t.Fatalf("%v (policy %d)", err, tc.policy)
+		}
+	}
+}
+
+func testSetReference(policy ReferencePolicy, removeErr error, postExists bool, postUserID string) (err error) {
+	var d *DB
+	if d, err = testInitDB(); err != nil {
+		return
+	}
+	defer testTeardownDB(d)
+
+	var users, posts *Mojura
+	if users, err = d.NewCollection("users", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		return
+	}
+
+	if posts, err = d.NewCollection("posts", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		return
+	}
+
+	if err = posts.SetReference("users", users, policy); err != nil {
+		return
+	}
+
+	if _, err = posts.New(newTestStruct("00000000", "", "", "post")); err != ErrReferencedEntryNotFound {
+		return fmt.Errorf("invalid error, expected %v and received %v", ErrReferencedEntryNotFound, err)
+	}
+
+	var userID, postID string
+	if userID, err = users.New(newTestStruct("", "", "", "user")); err != nil {
+		return
+	}
+
+	if postID, err = posts.New(newTestStruct(userID, "", "", "post")); err != nil {
+		return
+	}
+
+	if err = users.Remove(userID); err != removeErr {
+		return fmt.Errorf("invalid error, expected %v and received %v", removeErr, err)
+	}
+
+	var post testStruct
+	switch err = posts.Get(postID, &post); {
+	case postExists && err != nil:
+		return
+	case !postExists && err != ErrEntryNotFound:
+		return fmt.Errorf("invalid error, expected %v and received %v", ErrEntryNotFound, err)
+	case !postExists:
+		return nil
+	}
+
+	if post.UserID != postUserID {
+		return fmt.Errorf("invalid user ID, expected \"%s\" and received \"%s\"", postUserID, post.UserID)
+	}
+
+	return
+}
+
+func TestMojura_SetReference_restrict_before_remove(t *testing.T) {
+	var (
+		d   *DB
+		err error
+	)
+
+	if d, err = testInitDB(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardownDB(d)
+
+	var users, posts, comments *Mojura
+	if users, err = d.NewCollection("users", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if posts, err = d.NewCollection("posts", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if comments, err = d.NewCollection("comments", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = posts.SetReference("users", users, ReferenceRestrict); err != nil {
+		t.Fatal(err)
+	}
+
+	// Comments restrict the removal of posts, which are removed by cascade when their group is removed
+	if err = comments.SetReference("contacts", posts, ReferenceRestrict); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = posts.SetReference("groups", users, ReferenceCascade); err != nil {
+		t.Fatal(err)
+	}
+
+	var userID, groupID, postID string
+	if userID, err = users.New(newTestStruct("", "contact_0", "", "user")); err != nil {
+		t.Fatal(err)
+	}
+
+	if groupID, err = users.New(newTestStruct("", "contact_1", "", "group")); err != nil {
+		t.Fatal(err)
+	}
+
+	if postID, err = posts.New(newTestStruct(userID, "", groupID, "post")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = comments.New(newTestStruct("", postID, "", "comment")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entryID := range []string{userID, groupID} {
+		// The restricted error is swallowed, the transaction will be committed
+		if err = users.Transaction(context.Background(), func(txn *Transaction) (err error) {
+			if err = txn.Remove(entryID); err != ErrReferenceRestricted {
+				return fmt.Errorf("invalid error, expected %v and received %v", ErrReferenceRestricted, err)
+			}
+
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = users.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		if count := txn.getEntriesCount(); count != 2 {
+			return fmt.Errorf("invalid number of entries, expected %d and received %d", 2, count)
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	type testcase struct {
+		filter   Filter
+		expected []string
+	}
+
+	tcs := []testcase{
+		{filter: filters.Match("contacts", "contact_0"), expected: []string{userID}},
+		{filter: filters.Match("contacts", "contact_1"), expected: []string{groupID}},
+		{filter: filters.MissingRelationship("users"), expected: []string{userID, groupID}},
+	}
+
+	for i, tc := range tcs {
+		var entries []*testStruct
+		if _, err = users.GetFiltered(&entries, NewFilteringOpts(tc.filter)); err != nil {
+			t.Fatalf("test case #%d: %v", i, err)
+		}
+
+		if err = testCheckIDs(entries, tc.expected); err != nil {
+			t.Fatalf("test case #%d: %v", i, err)
+		}
+	}
+
+	var post testStruct
+	if err = posts.Get(postID, &post); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMojura_GetFilteredWithIncluded(t *testing.T) {
+	var (
+		d   *DB
+		err error
+	)
+
+	if d, err = testInitDB(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardownDB(d)
+
+	var customers, orders *Mojura
+	if customers, err = d.NewCollection("customers", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if orders, err = d.NewCollection("orders", &testStruct{}, "users", "contacts", "groups", "tags"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = orders.SetReference("users", customers, ReferenceRestrict); err != nil {
+		t.Fatal(err)
+	}
+
+	var customerA, customerB string
+	if customerA, err = customers.New(newTestStruct("", "", "", "customer a")); err != nil {
+		t.Fatal(err)
+	}
+
+	if customerB, err = customers.New(newTestStruct("", "", "", "customer b")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, customerID := range []string{customerA, customerB, customerA} {
+		if _, err = orders.New(newTestStruct(customerID, "", "group_1", "order")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		entries  []*testStruct
+		included Included
+	)
+
+	opts := NewFilteringOpts(filters.Match("groups", "group_1"))
+	opts.Include = []string{"users"}
+	if _, included, err = orders.GetFilteredWithIncluded(&entries, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 3, len(entries))
+	}
+
+	if len(included["users"]) != 2 {
+		t.Fatalf("invalid number of included entries, expected %d and received %d", 2, len(included["users"]))
+	}
+
+	for _, entry := range entries {
+		val, ok := included.Get("users", entry.UserID)
+		if !ok {
+			t.Fatalf("expected included entry for <%s> was not found", entry.UserID)
+		}
+
+		if val.GetID() != entry.UserID {
+			t.Fatalf("invalid included entry, expected <%s> and received <%s>", entry.UserID, val.GetID())
+		}
+	}
+}
+
+func testInitDB() (d *DB, err error) {
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		return
+	}
+
+	return NewDB("test", testDir)
+}
+
+func testTeardownDB(d *DB) (err error) {
+	var errs errors.ErrorList
+	if d != nil {
+		errs.Push(d.Close())
+	}
+
+	errs.Push(os.RemoveAll(testDir))
+	return errs.Err()
+}
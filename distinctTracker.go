@@ -0,0 +1,195 @@
+package mojura
+
+import (
+	"bytes"
+
+	"github.com/mojura/backend"
+)
+
+// distinctTracker ensures entries with multiple matching relationship IDs are only yielded once per iteration
+// Note: Entries are yielded at their first matching relationship ID in the direction entries are positioned in
+// (the direction of the query). When a walk does not begin at the first bucket (e.g. after a seek), the entries
+// of the buckets preceding the walk are collected once so that pagination remains correct. Walks against the
+// direction entries are positioned in (e.g. a previous page) collect the position of each entry of the buckets
+// preceding the walk instead. The memory used by a walk grows with the entries it has yielded and the entries
+// of the matching buckets which precede it
+type distinctTracker struct {
+	parent backend.Bucket
+	// isMatch will return whether or not the bucket of a relationship ID is iterated
+	isMatch func(relationshipID []byte) (ok bool, err error)
+	// isBeyond will return whether or not no further buckets are iterated past the relationship ID in the provided direction
+	isBeyond func(relationshipID []byte, reverse bool) (ok bool)
+	// Whether or not entries are positioned at their first matching relationship ID in reverse
+	positionReverse bool
+
+	seen map[string]struct{}
+	// Entries of the matching buckets at or preceding the boundary, set to true when an entry exists within
+	// a bucket other than the boundary. Nil until the first entry of a walk from a boundary is checked
+	preceded map[string]bool
+	// Relationship ID each entry of the matching buckets at or preceding the boundary is positioned at, only
+	// set for walks against the direction entries are positioned in. Nil until the first entry is checked
+	positions map[string]string
+	// Relationship ID which the current walk began at, nil when the walk began at the first bucket
+	boundary []byte
+	reverse  bool
+}
+
+// reset will begin a new walk from the provided boundary
+func (d *distinctTracker) reset(boundary []byte, reverse bool) {
+	d.seen = make(map[string]struct{})
+	d.preceded = nil
+	d.positions = nil
+	d.boundary = nil
+	if boundary != nil {
+		d.boundary = append([]byte{}, boundary...)
+	}
+
+	d.reverse = reverse
+}
+
+func (d *distinctTracker) isDistinct(relationshipID, entryID []byte, reverse bool) (ok bool, err error) {
+	if d.seen == nil || reverse != d.reverse {
+		// Direction has changed, previously seen entries no longer precede the walk
+		d.reset(relationshipID, reverse)
+	}
+
+	if reverse != d.positionReverse {
+		// Walk is against the direction entries are positioned in, entries are only yielded at their position
+		return d.isPosition(relationshipID, entryID)
+	}
+
+	if _, seen := d.seen[string(entryID)]; seen {
+		return false, nil
+	}
+
+	if d.boundary != nil {
+		var preceded bool
+		if preceded, err = d.isPreceded(relationshipID, entryID); err != nil || preceded {
+			return
+		}
+	}
+
+	d.seen[string(entryID)] = struct{}{}
+	return true, nil
+}
+
+// isPreceded will determine if an entry exists within a matching bucket at or preceding the boundary
+// Note: Entries are not preceded by the bucket they are being yielded from
+func (d *distinctTracker) isPreceded(relationshipID, entryID []byte) (ok bool, err error) {
+	if d.preceded == nil {
+		if err = d.setPreceded(); err != nil {
+			return
+		}
+	}
+
+	outsideBoundary, exists := d.preceded[string(entryID)]
+	return exists && (outsideBoundary || !bytes.Equal(relationshipID, d.boundary)), nil
+}
+
+// setPreceded will collect the entries of the matching buckets at or preceding the boundary
+func (d *distinctTracker) setPreceded() (err error) {
+	d.preceded = make(map[string]bool)
+	cur := d.parent.Cursor()
+	bktKey, _ := cur.Seek(d.boundary)
+	step := cur.Next
+	if !d.reverse {
+		// Forward walks are preceded by the buckets before the boundary
+		step = cur.Prev
+		switch {
+		case bktKey == nil:
+			bktKey, _ = cur.Last()
+		case !bytes.Equal(bktKey, d.boundary):
+			bktKey, _ = cur.Prev()
+		}
+	}
+
+	for ; bktKey != nil; bktKey, _ = step() {
+		if d.isBeyond(bktKey, !d.reverse) {
+			return
+		}
+
+		var isMatch bool
+		if isMatch, err = d.isMatch(bktKey); err != nil {
+			return
+		}
+
+		if !isMatch {
+			continue
+		}
+
+		bkt := d.parent.GetBucket(bktKey)
+		if bkt == nil {
+			continue
+		}
+
+		outsideBoundary := !bytes.Equal(bktKey, d.boundary)
+		if err = bkt.ForEach(func(entryID, _ []byte) (err error) {
+			d.preceded[string(entryID)] = d.preceded[string(entryID)] || outsideBoundary
+			return
+		}); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// isPosition will determine if a relationship ID is the position of an entry
+func (d *distinctTracker) isPosition(relationshipID, entryID []byte) (ok bool, err error) {
+	if d.positions == nil {
+		if err = d.setPositions(); err != nil {
+			return
+		}
+	}
+
+	position, exists := d.positions[string(entryID)]
+	return exists && position == string(relationshipID), nil
+}
+
+// setPositions will collect the position of each entry of the matching buckets at or preceding the boundary
+// Note: Buckets are iterated in the direction entries are positioned in, a nil boundary collects all buckets
+func (d *distinctTracker) setPositions() (err error) {
+	d.positions = make(map[string]string)
+	cur := d.parent.Cursor()
+	first, step, beyondBoundary := cur.First, cur.Next, 1
+	if d.positionReverse {
+		first, step, beyondBoundary = cur.Last, cur.Prev, -1
+	}
+
+	for bktKey, _ := first(); bktKey != nil; bktKey, _ = step() {
+		if d.isBeyond(bktKey, d.positionReverse) {
+			return
+		}
+
+		if d.boundary != nil && bytes.Compare(bktKey, d.boundary) == beyondBoundary {
+			return
+		}
+
+		var isMatch bool
+		if isMatch, err = d.isMatch(bktKey); err != nil {
+			return
+		}
+
+		if !isMatch {
+			continue
+		}
+
+		bkt := d.parent.GetBucket(bktKey)
+		if bkt == nil {
+			continue
+		}
+
+		position := string(bktKey)
+		if err = bkt.ForEach(func(entryID, _ []byte) (err error) {
+			if _, exists := d.positions[string(entryID)]; !exists {
+				d.positions[string(entryID)] = position
+			}
+
+			return
+		}); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,164 @@
+package mojura
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func Test_distinctTracker(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "", "a", "b"),
+		newTestStruct("user_0", "contact_0", "group_0", "", "b"),
+		newTestStruct("user_0", "contact_0", "group_0", "", "c", "a"),
+		newTestStruct("user_0", "contact_0", "group_0", "", "d"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Range("tags", "a", "c")},
+			expected: []string{"00000000", "00000002", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Range("tags", "a", "c")},
+			reverse:  true,
+			expected: []string{"00000002", "00000001", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.Prefix("tags", "")},
+			expected: []string{"00000000", "00000002", "00000001", "00000003"},
+		},
+	})
+
+	type testcase struct {
+		filter   Filter
+		reverse  bool
+		expected []string
+	}
+
+	tcs := []testcase{
+		{
+			filter:   filters.Range("tags", "a", "c"),
+			expected: []string{"00000000", "00000002", "00000001"},
+		},
+		{
+			filter:   filters.Range("tags", "a", "c"),
+			reverse:  true,
+			expected: []string{"00000002", "00000001", "00000000"},
+		},
+		{
+			// Inverse filters are probed while all entries are scanned
+			filter:   filters.InverseMatch("tags", "d"),
+			expected: []string{"00000000", "00000001", "00000002"},
+		},
+		{
+			filter:   filters.InverseMatch("tags", "d"),
+			reverse:  true,
+			expected: []string{"00000002", "00000001", "00000000"},
+		},
+	}
+
+	for i, tc := range tcs {
+		// Paginate one entry at a time to ensure entries from previous pages are not repeated
+		o := NewFilteringOpts(tc.filter)
+		o.Reverse = tc.reverse
+		o.Limit = 1
+		// Inverse filters do not drive iteration, even when the filter order is preserved
+		o.PreserveFilterOrder = true
+
+		var ids []string
+		for page := 0; page < len(tc.expected)+1; page++ {
+			var filtered []*testStruct
+			if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+				t.Fatalf("test case #%d: %v", i, err)
+			}
+
+			for _, entry := range filtered {
+				ids = append(ids, entry.ID)
+			}
+
+			if o.LastID == "" {
+				break
+			}
+		}
+
+		if len(ids) != len(tc.expected) {
+			t.Fatalf("test case #%d: invalid IDs, expected %v and received %v", i, tc.expected, ids)
+		}
+
+		for j, id := range ids {
+			if id != tc.expected[j] {
+				t.Fatalf("test case #%d: invalid IDs, expected %v and received %v", i, tc.expected, ids)
+			}
+		}
+	}
+
+	// Distinct can be disabled to yield entries once per matching relationship ID
+	o := NewFilteringOpts(filters.Range("tags", "a", "c"))
+	o.Distinct = false
+
+	var filtered []*testStruct
+	if _, err = m.GetFiltered(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckIDs(filtered, []string{"00000000", "00000002", "00000000", "00000001", "00000002"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Benchmark_distinctTracker_page(b *testing.B) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		b.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	// Entries have two tags, so each tag bucket shares entries with the buckets around it
+	for i := 0; i < 1000; i++ {
+		tags := []string{fmt.Sprintf("tag_%03d", i%100), fmt.Sprintf("tag_%03d", (i+1)%100)}
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "", tags...)); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	// Position the page past the middle of the range so the preceding buckets are probed
+	o := NewFilteringOpts(filters.Prefix("tags", "tag_"))
+	o.Limit = 500
+	var filtered []*testStruct
+	if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+		b.Fatal(err)
+	}
+
+	o.Limit = 10
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filtered = filtered[:0]
+		if _, err = m.GetFiltered(&filtered, o); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+}
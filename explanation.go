@@ -0,0 +1,75 @@
+package mojura
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Explanation describes how a filtered query was executed
+type Explanation struct {
+	// Query represents the filters of the query in their text form
+	Query string `json:"query"`
+
+	// Primary represents the filter which drove iteration
+	// Note: When nil, iteration was driven by scanning all entries
+	Primary Filter `json:"primary"`
+	// Secondary represents the filters which were probed for each entry of the primary
+	Secondary []Filter `json:"secondary"`
+	// Cursors represents the statistics of each filter cursor, starting with the primary
+	Cursors []CursorExplanation `json:"cursors"`
+	// PostFilters represents the predicate filters which were applied to decoded entries
+	PostFilters []Filter `json:"postFilters"`
+
+	// EntriesDecoded represents the number of entries which were decoded
+	EntriesDecoded int64 `json:"entriesDecoded"`
+	// EntriesRejected represents the number of decoded entries which did not match the post filters
+	EntriesRejected int64 `json:"entriesRejected"`
+	// Elapsed represents the duration of the query
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// String will return a human readable summary of the explanation
+func (e *Explanation) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "query <%s> took %v, %d entries decoded\n", e.Query, e.Elapsed, e.EntriesDecoded)
+	for _, c := range e.Cursors {
+		fmt.Fprintf(&sb, "\t%s\n", c.String())
+	}
+
+	for _, f := range e.PostFilters {
+		fmt.Fprintf(&sb, "\tpost-filter <%s>\n", describeFilter(f))
+	}
+
+	if len(e.PostFilters) > 0 {
+		fmt.Fprintf(&sb, "\t%d entries rejected by post-filters\n", e.EntriesRejected)
+	}
+
+	return sb.String()
+}
+
+// CursorExplanation describes the work performed by a filter cursor
+type CursorExplanation struct {
+	// Filter represents the filter of the cursor
+	// Note: When nil, the cursor scanned all entries
+	Filter Filter `json:"filter"`
+	// Primary represents whether or not the cursor drove iteration
+	Primary bool `json:"primary"`
+
+	// EstimatedKeys represents the number of entries the query planner expected the filter to match
+	EstimatedKeys int64 `json:"estimatedKeys"`
+	// KeysScanned represents the number of keys the cursor scanned
+	KeysScanned int64 `json:"keysScanned"`
+	// Probes represents the number of HasForward/HasReverse calls made against the cursor
+	Probes int64 `json:"probes"`
+}
+
+// String will return a human readable summary of the cursor explanation
+func (c *CursorExplanation) String() string {
+	role := "secondary"
+	if c.Primary {
+		role = "primary"
+	}
+
+	return fmt.Sprintf("%s <%s>: estimated %d keys, scanned %d keys, %d probes", role, describeFilter(c.Filter), c.EstimatedKeys, c.KeysScanned, c.Probes)
+}
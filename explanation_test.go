@@ -0,0 +1,154 @@
+package mojura
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func TestMojura_Explain(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 10; i++ {
+		userID := fmt.Sprintf("user_%d", i%5)
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var e *Explanation
+	if e, err = m.Explain(NewFilteringOpts(filters.Match("groups", "group_0"), filters.Match("users", "user_0"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `groups = "group_0" AND users = "user_0"`; e.Query != expected {
+		t.Fatalf("invalid query, expected <%s> and received <%s>", expected, e.Query)
+	}
+
+	if f, ok := e.Primary.(*filters.MatchFilter); !ok || f.RelationshipKey != "users" {
+		t.Fatalf("invalid primary, expected the users filter and received %+v", e.Primary)
+	}
+
+	if len(e.Secondary) != 1 || len(e.Cursors) != 2 {
+		t.Fatalf("invalid number of filters, expected 1 secondary and 2 cursors and received %d and %d", len(e.Secondary), len(e.Cursors))
+	}
+
+	primary := e.Cursors[0]
+	switch {
+	case !primary.Primary:
+		t.Fatal("expected the first cursor to be the primary")
+	case primary.EstimatedKeys != 2:
+		t.Fatalf("invalid primary estimate, expected %d and received %d", 2, primary.EstimatedKeys)
+	case primary.KeysScanned < 2:
+		t.Fatalf("invalid primary keys scanned, expected at least %d and received %d", 2, primary.KeysScanned)
+	case primary.Probes != 0:
+		t.Fatalf("invalid primary probes, expected %d and received %d", 0, primary.Probes)
+	}
+
+	secondary := e.Cursors[1]
+	switch {
+	case secondary.Primary:
+		t.Fatal("expected the second cursor to be a secondary")
+	case secondary.EstimatedKeys != 10:
+		t.Fatalf("invalid secondary estimate, expected %d and received %d", 10, secondary.EstimatedKeys)
+	case secondary.Probes != 2:
+		t.Fatalf("invalid secondary probes, expected %d and received %d", 2, secondary.Probes)
+	}
+
+	if e.EntriesDecoded != 2 {
+		t.Fatalf("invalid entries decoded, expected %d and received %d", 2, e.EntriesDecoded)
+	}
+
+	if e.Elapsed <= 0 {
+		t.Fatal("expected elapsed to be set")
+	}
+}
+
+func TestMojura_Explain_all(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 4; i++ {
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o := NewFilteringOpts()
+	o.Limit = 3
+
+	var e *Explanation
+	if e, err = m.Explain(o); err != nil {
+		t.Fatal(err)
+	}
+
+	switch {
+	case e.Primary != nil:
+		t.Fatalf("expected entries scan and received primary of %+v", e.Primary)
+	case len(e.Cursors) != 1:
+		t.Fatalf("invalid number of cursors, expected %d and received %d", 1, len(e.Cursors))
+	case e.Cursors[0].EstimatedKeys != 4:
+		t.Fatalf("invalid estimate, expected %d and received %d", 4, e.Cursors[0].EstimatedKeys)
+	case e.EntriesDecoded != 3:
+		t.Fatalf("invalid entries decoded, expected %d and received %d", 3, e.EntriesDecoded)
+	}
+
+	if !strings.Contains(e.String(), "primary <all entries>: estimated 4 keys") {
+		t.Fatalf("invalid explanation string: %s", e.String())
+	}
+}
+
+func TestMojura_SlowQueryThreshold(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	var l testLogger
+	opts := defaultOpts
+	opts.SlowQueryThreshold = time.Nanosecond
+	opts.Logger = &l
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []*testStruct
+	if _, err = m.GetFiltered(&entries, NewFilteringOpts(filters.Match("users", "user_0"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.messages) != 1 || !strings.Contains(l.messages[0], `slow query <users = "user_0">`) {
+		t.Fatalf("invalid log messages, expected a slow query message and received %v", l.messages)
+	}
+}
+
+type testLogger struct {
+	messages []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
@@ -1,5 +1,6 @@
 package mojura
 
+import "github.com/mojura/mojura/filters"
+
 // Filter can generate a filter cursor
-type Filter interface {
-}
+type Filter = filters.Filter
@@ -7,19 +7,60 @@ import (
 )
 
 func newFilterCursor(txn *Transaction, f Filter) (fc filterCursor, err error) {
-	switch n := f.(type) {
+	switch n := txn.m.normalizeFilter(f).(type) {
 	case *filters.MatchFilter:
 		return newMatchCursor(txn, n)
 	case *filters.InverseMatchFilter:
 		return newInverseMatchCursor(txn, n)
 	case *filters.ComparisonFilter:
 		return newComparisonCursor(txn, n)
+	case *filters.PrefixFilter:
+		return newPrefixCursor(txn, n)
+	case *filters.HasRelationshipFilter:
+		return newHasRelationshipCursor(txn, n)
+	case *filters.MissingRelationshipFilter:
+		return newMissingRelationshipCursor(txn, n)
+	case *filters.OrFilter:
+		return newOrCursor(txn, n)
+	case *filters.AndFilter:
+		return newAndCursor(txn, n)
+	case *filters.NotFilter:
+		return newNotCursor(txn, n)
+	case *filters.TextFilter:
+		return newTextCursor(txn, n)
+	case *filters.InFilter:
+		return newInCursor(txn, n)
+	case *filters.NotInFilter:
+		return newNotInCursor(txn, n)
 	default:
 		err = fmt.Errorf("filter of %T is not supported", n)
 		return
 	}
 }
 
+// isEntryOrdered will return whether or not a filter cursor iterates in entry ID order
+func isEntryOrdered(fc filterCursor) (ok bool) {
+	switch n := fc.(type) {
+	case *matchCursor, *orCursor, *sortedIDsCursor, *hasRelationshipCursor, *notCursor, *nopCursor:
+		return true
+	case *andCursor:
+		// Conjunctions iterate in the order of their primary
+		return isEntryOrdered(n.primary)
+
+	default:
+		return false
+	}
+}
+
+// newEntryOrderedCursor will collect the entry IDs of a filter cursor which does not iterate in entry ID order
+func newEntryOrderedCursor(txn *Transaction, fc filterCursor) (cur filterCursor, err error) {
+	if ic, ok := fc.(*inverseMatchCursor); ok {
+		return newInverseSortedIDsCursor(txn, ic)
+	}
+
+	return newSortedIDsCursor(txn, fc)
+}
+
 type filterCursor interface {
 	SeekForward(relationshipKey, seekID []byte) (entryID []byte, err error)
 	SeekReverse(relationshipKey, seekID []byte) (entryID []byte, err error)
@@ -34,3 +75,9 @@ type filterCursor interface {
 
 	getCurrentRelationshipID() (relationshipID string)
 }
+
+// distinctCursor is a filter cursor which can yield entries with multiple matching relationship IDs once
+type distinctCursor interface {
+	// enableDistinct will position entries at their first matching relationship ID in the provided direction
+	enableDistinct(reverse bool)
+}
@@ -1,12 +1,13 @@
 package mojura
 
-var defaultFilteringOpts = &FilteringOpts{Limit: -1}
+var defaultFilteringOpts = &FilteringOpts{IteratingOpts: IteratingOpts{Distinct: true}, Limit: -1}
 
 // NewFilteringOpts will initialize a new instance of Filtering Opts
 func NewFilteringOpts(fs ...Filter) *FilteringOpts {
 	var f FilteringOpts
 	f.Filters = fs
 	f.Limit = defaultFilteringOpts.Limit
+	f.Distinct = defaultFilteringOpts.Distinct
 	return &f
 }
 
@@ -14,4 +15,20 @@ func NewFilteringOpts(fs ...Filter) *FilteringOpts {
 type FilteringOpts struct {
 	IteratingOpts
 	Limit int64
+	// PageToken represents a token returned within a Page, used to retrieve the corresponding page
+	// Note: When set, LastID and Reverse are ignored as the token holds the position and direction
+	PageToken string
+	// PageQueryID identifies the query a page token is created for. Page tokens are only valid for the query
+	// they were created for, which is determined by the text form of the filters. Filters without a text form
+	// (predicates and custom comparisons) cannot be told apart, so GetPage requires a PageQueryID when they are set
+	// Note: The PageQueryID is expected to change whenever the predicates or custom comparisons change
+	PageQueryID string
+	// RankByText will return entries ordered by the frequency of the terms of the text filters (see filters.Text)
+	// Note: Ranked queries score every matching entry for each page while only holding Limit entries in memory,
+	// LastID represents the ranked position of the last entry. Ranking is not supported by GetPage
+	RankByText bool
+	// Include represents the relationship keys whose related entries should be eagerly loaded. Relationship IDs
+	// are expected to be entry IDs of the referenced collection (see SetReference) or of the same collection
+	// Note: Included entries are only returned by the GetFilteredWithIncluded methods
+	Include []string
 }
@@ -0,0 +1,16 @@
+package filters
+
+// And creates a new and filter
+func And(fs ...Filter) *AndFilter {
+	var a AndFilter
+	a.Filters = fs
+	return &a
+}
+
+// AndFilter will match entries which match all of the provided filters
+// Note: Top level filters are already combined with AND, and filters are used to nest
+// conjunctions within or and not filters
+type AndFilter struct {
+	// Filters represents the filters to match against
+	Filters []Filter `json:"filters"`
+}
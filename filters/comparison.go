@@ -1,5 +1,22 @@
 package filters
 
+import (
+	"encoding/json"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// ErrStopAfter can be returned by a ComparisonFn to signal that the relationship ID does not match and
+	// that no relationship IDs which sort after it will match. Forward iteration will end early while
+	// reverse iteration will continue to the relationship IDs which precede it
+	ErrStopAfter = errors.Error("no relationship IDs after the provided relationship ID will match")
+	// ErrStopBefore can be returned by a ComparisonFn to signal that the relationship ID does not match and
+	// that no relationship IDs which sort before it will match. Reverse iteration will end early while
+	// forward iteration will continue to the relationship IDs which follow it
+	ErrStopBefore = errors.Error("no relationship IDs before the provided relationship ID will match")
+)
+
 // Comparison creates a new comparison Filter
 func Comparison(relationshipKey string, comparison ComparisonFn) *ComparisonFilter {
 	return ComparisonWithRange(relationshipKey, "", "", comparison)
@@ -18,12 +35,46 @@ func ComparisonWithRange(relationshipKey, rangeStart, rangeEnd string, compariso
 // ComparisonFilter represents a relationship key and ID
 type ComparisonFilter struct {
 	RelationshipKey string `json:"relationshipKey"`
-	// TODO: implement MQL here when available
+	// Comparison is not serializable, Operator and Value are used to re-create
+	// the comparison when a filter is decoded
 	Comparison ComparisonFn `json:"-"`
 
+	// Operator represents the serializable comparison operator
+	// Note: Operator is unset for comparison filters created with custom comparison funcs
+	Operator Operator `json:"operator,omitempty"`
+	// Value represents the value the operator compares against
+	Value string `json:"value,omitempty"`
+
 	RangeStart string `json:"rangeStart"`
 	RangeEnd   string `json:"rangeEnd"`
 }
 
+// IsSerializable will return whether or not the comparison can be re-created from its encoded form
+func (c *ComparisonFilter) IsSerializable() (ok bool) {
+	return c.Operator.Validate() == nil
+}
+
+// UnmarshalJSON is a JSON decoding helper func
+func (c *ComparisonFilter) UnmarshalJSON(bs []byte) (err error) {
+	type comparisonFilter ComparisonFilter
+	var decoded comparisonFilter
+	if err = json.Unmarshal(bs, &decoded); err != nil {
+		return
+	}
+
+	if len(decoded.Operator) == 0 {
+		return ErrUnserializableComparison
+	}
+
+	if decoded.Comparison, err = decoded.Operator.getComparisonFn(decoded.Value); err != nil {
+		return
+	}
+
+	*c = ComparisonFilter(decoded)
+	return
+}
+
 // ComparisonFn is used for comparison filters
+// Note: Relationship IDs are compared in sorted order, ErrStopAfter and ErrStopBefore can be returned
+// to end iteration early when no further relationship IDs will match
 type ComparisonFn func(relationshipID string) (ok bool, err error)
@@ -0,0 +1,5 @@
+package filters
+
+// Filter represents a filter which can be used to generate a filter cursor
+type Filter interface {
+}
@@ -0,0 +1,30 @@
+package filters
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestInFilter_JSON(t *testing.T) {
+	testJSONRoundTrip(t, In("users", "user_0", "user_1"), &InFilter{})
+}
+
+func TestNotInFilter_JSON(t *testing.T) {
+	testJSONRoundTrip(t, NotIn("users", "user_0", "user_1"), &NotInFilter{})
+}
+
+func testJSONRoundTrip(t *testing.T, f, target interface{}) {
+	bs, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(bs, target); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(f, target) {
+		t.Fatalf("invalid value, expected %+v and received %+v", f, target)
+	}
+}
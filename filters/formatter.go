@@ -0,0 +1,155 @@
+package filters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// formatter formats filters as queries which can be read by the parser
+type formatter struct {
+	strings.Builder
+}
+
+func (f *formatter) formatAll(fs []Filter) (err error) {
+	for i, filter := range fs {
+		if i > 0 {
+			f.WriteString(" AND ")
+		}
+
+		if err = f.format(filter); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (f *formatter) format(filter Filter) (err error) {
+	switch n := filter.(type) {
+	case *MatchFilter:
+		return f.writeComparison(n.RelationshipKey, "=", n.RelationshipID)
+	case *InverseMatchFilter:
+		return f.writeComparison(n.RelationshipKey, "!=", n.RelationshipID)
+	case *ComparisonFilter:
+		return f.formatComparison(n)
+	case *PrefixFilter:
+		return f.writeComparison(n.RelationshipKey, keywordPrefix, n.Prefix)
+	case *InFilter:
+		return f.writeValues(n.RelationshipKey, keywordIn, n.RelationshipIDs)
+	case *NotInFilter:
+		return f.writeValues(n.RelationshipKey, keywordNot+" "+keywordIn, n.RelationshipIDs)
+	case *HasRelationshipFilter:
+		return f.writeKeyword(keywordHas, n.RelationshipKey)
+	case *MissingRelationshipFilter:
+		return f.writeKeyword(keywordMissing, n.RelationshipKey)
+	case *OrFilter:
+		return f.formatGroup(n.Filters, keywordOr)
+	case *AndFilter:
+		return f.formatGroup(n.Filters, keywordAnd)
+	case *NotFilter:
+		return f.formatNot(n)
+
+	default:
+		return fmt.Errorf("%w, filter of %T cannot be formatted", ErrUnsupportedFilter, filter)
+	}
+}
+
+func (f *formatter) formatComparison(c *ComparisonFilter) (err error) {
+	switch c.Operator {
+	case "":
+		return ErrUnserializableComparison
+	case OperatorBetween:
+		if err = f.writeKey(c.RelationshipKey); err != nil {
+			return
+		}
+
+		fmt.Fprintf(f, " %s %s %s %s", keywordBetween, strconv.Quote(c.RangeStart), keywordAnd, strconv.Quote(c.RangeEnd))
+		return
+
+	default:
+		if err = c.Operator.Validate(); err != nil {
+			return
+		}
+
+		return f.writeComparison(c.RelationshipKey, string(c.Operator), c.Value)
+	}
+}
+
+func (f *formatter) formatGroup(fs []Filter, connector string) (err error) {
+	if len(fs) == 0 {
+		return fmt.Errorf("%w, %s filters cannot be empty", ErrUnsupportedFilter, strings.ToLower(connector))
+	}
+
+	f.WriteString("(")
+	for i, filter := range fs {
+		if i > 0 {
+			fmt.Fprintf(f, " %s ", connector)
+		}
+
+		if err = f.format(filter); err != nil {
+			return
+		}
+	}
+
+	f.WriteString(")")
+	return
+}
+
+func (f *formatter) formatNot(n *NotFilter) (err error) {
+	f.WriteString(keywordNot)
+	switch n.Filter.(type) {
+	case *OrFilter, *AndFilter:
+		// Groups are already wrapped in parentheses
+		f.WriteString(" ")
+		return f.format(n.Filter)
+
+	default:
+		// Negated filters are wrapped in parentheses so they are not parsed as their inverse form
+		f.WriteString(" (")
+		if err = f.format(n.Filter); err != nil {
+			return
+		}
+
+		f.WriteString(")")
+		return
+	}
+}
+
+func (f *formatter) writeKey(key string) (err error) {
+	if !isIdent(key) {
+		return fmt.Errorf("%w, invalid relationship key <%s>", ErrUnsupportedFilter, key)
+	}
+
+	f.WriteString(key)
+	return
+}
+
+func (f *formatter) writeKeyword(keyword, key string) (err error) {
+	f.WriteString(keyword)
+	f.WriteString(" ")
+	return f.writeKey(key)
+}
+
+func (f *formatter) writeComparison(key, op, value string) (err error) {
+	if err = f.writeKey(key); err != nil {
+		return
+	}
+
+	fmt.Fprintf(f, " %s %s", op, strconv.Quote(value))
+	return
+}
+
+func (f *formatter) writeValues(key, op string, values []string) (err error) {
+	if err = f.writeKey(key); err != nil {
+		return
+	}
+
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+
+	fmt.Fprintf(f, " %s (%s)", op, strings.Join(quoted, ", "))
+	return
+}
@@ -0,0 +1,17 @@
+package filters
+
+// In creates a new in filter
+func In(relationshipKey string, relationshipIDs ...string) *InFilter {
+	var i InFilter
+	i.RelationshipKey = relationshipKey
+	i.RelationshipIDs = relationshipIDs
+	return &i
+}
+
+// InFilter will match against a relationship key and any of the provided relationship IDs
+type InFilter struct {
+	// Relationship represents the relationship to target
+	RelationshipKey string `json:"relationshipKey"`
+	// RelationshipIDs represents the IDs of the corasponding relationship
+	RelationshipIDs []string `json:"relationshipIDs"`
+}
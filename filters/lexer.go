@@ -0,0 +1,186 @@
+package filters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+const (
+	keywordAnd     = "AND"
+	keywordOr      = "OR"
+	keywordNot     = "NOT"
+	keywordIn      = "IN"
+	keywordPrefix  = "PREFIX"
+	keywordHas     = "HAS"
+	keywordMissing = "MISSING"
+	keywordBetween = "BETWEEN"
+)
+
+var keywords = []string{
+	keywordAnd,
+	keywordOr,
+	keywordNot,
+	keywordIn,
+	keywordPrefix,
+	keywordHas,
+	keywordMissing,
+	keywordBetween,
+}
+
+type tokenType uint8
+
+type token struct {
+	typ   tokenType
+	value string
+	// Position of the token within the query
+	pos int
+}
+
+func (t token) isKeyword(keyword string) (ok bool) {
+	return t.typ == tokenIdent && strings.EqualFold(t.value, keyword)
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of query"
+	}
+
+	return fmt.Sprintf("<%s>", t.value)
+}
+
+func isKeyword(value string) (ok bool) {
+	for _, keyword := range keywords {
+		if strings.EqualFold(value, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIdentRune(r rune) (ok bool) {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r):
+		return true
+	case strings.ContainsRune("_-.:/", r):
+		return true
+
+	default:
+		return false
+	}
+}
+
+func isIdent(value string) (ok bool) {
+	if len(value) == 0 || isKeyword(value) {
+		return false
+	}
+
+	for _, r := range value {
+		if !isIdentRune(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lex(query string) (tokens []token, err error) {
+	rs := []rune(query)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{typ: tokenLeftParen, value: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{typ: tokenRightParen, value: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{typ: tokenComma, value: ",", pos: i})
+			i++
+		case r == '"':
+			var t token
+			if t, i, err = lexString(rs, i); err != nil {
+				return
+			}
+
+			tokens = append(tokens, t)
+		case strings.ContainsRune("=!<>", r):
+			var t token
+			if t, i, err = lexOperator(rs, i); err != nil {
+				return
+			}
+
+			tokens = append(tokens, t)
+		case isIdentRune(r):
+			start := i
+			for i < len(rs) && isIdentRune(rs[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{typ: tokenIdent, value: string(rs[start:i]), pos: start})
+
+		default:
+			err = fmt.Errorf("%w, unexpected character <%c> at position %d", ErrInvalidQuery, r, i)
+			return
+		}
+	}
+
+	tokens = append(tokens, token{typ: tokenEOF, pos: len(rs)})
+	return
+}
+
+func lexString(rs []rune, start int) (t token, end int, err error) {
+	for end = start + 1; end < len(rs); end++ {
+		switch rs[end] {
+		case '\\':
+			// Skip escaped character
+			end++
+		case '"':
+			end++
+			t.typ = tokenString
+			t.pos = start
+			if t.value, err = strconv.Unquote(string(rs[start:end])); err != nil {
+				err = fmt.Errorf("%w, invalid string at position %d: %v", ErrInvalidQuery, start, err)
+			}
+
+			return
+		}
+	}
+
+	err = fmt.Errorf("%w, unterminated string at position %d", ErrInvalidQuery, start)
+	return
+}
+
+func lexOperator(rs []rune, start int) (t token, end int, err error) {
+	end = start + 1
+	if end < len(rs) && rs[end] == '=' {
+		end++
+	}
+
+	t.typ = tokenOperator
+	t.pos = start
+	t.value = string(rs[start:end])
+
+	switch t.value {
+	case "=", "!=", "<", "<=", ">", ">=":
+	default:
+		err = fmt.Errorf("%w, unexpected operator <%s> at position %d", ErrInvalidQuery, t.value, start)
+	}
+
+	return
+}
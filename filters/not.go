@@ -0,0 +1,15 @@
+package filters
+
+// Not creates a new not filter
+func Not(f Filter) *NotFilter {
+	var n NotFilter
+	n.Filter = f
+	return &n
+}
+
+// NotFilter will match entries which do not match the provided filter
+// Note: Unlike inverse match filters, entries without any relationships for a key will match
+type NotFilter struct {
+	// Filter represents the filter to negate
+	Filter Filter `json:"filter"`
+}
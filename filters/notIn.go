@@ -0,0 +1,17 @@
+package filters
+
+// NotIn creates a new not in filter
+func NotIn(relationshipKey string, relationshipIDs ...string) *NotInFilter {
+	var n NotInFilter
+	n.RelationshipKey = relationshipKey
+	n.RelationshipIDs = relationshipIDs
+	return &n
+}
+
+// NotInFilter will inverse match against a relationship key and all of the provided relationship IDs
+type NotInFilter struct {
+	// Relationship represents the relationship to target
+	RelationshipKey string `json:"relationshipKey"`
+	// RelationshipIDs represents the IDs of the corasponding relationship
+	RelationshipIDs []string `json:"relationshipIDs"`
+}
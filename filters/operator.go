@@ -0,0 +1,82 @@
+package filters
+
+import "fmt"
+
+const (
+	// OperatorLessThan represents a less than comparison
+	OperatorLessThan Operator = "<"
+	// OperatorLessThanOrEqualTo represents a less than or equal to comparison
+	OperatorLessThanOrEqualTo Operator = "<="
+	// OperatorGreaterThan represents a greater than comparison
+	OperatorGreaterThan Operator = ">"
+	// OperatorGreaterThanOrEqualTo represents a greater than or equal to comparison
+	OperatorGreaterThanOrEqualTo Operator = ">="
+	// OperatorBetween represents an inclusive range comparison
+	OperatorBetween Operator = "between"
+)
+
+// Operator represents a serializable comparison operator
+type Operator string
+
+// Validate will validate an operator
+func (o Operator) Validate() (err error) {
+	switch o {
+	case OperatorLessThan:
+	case OperatorLessThanOrEqualTo:
+	case OperatorGreaterThan:
+	case OperatorGreaterThanOrEqualTo:
+	case OperatorBetween:
+
+	default:
+		return fmt.Errorf("%w <%s>", ErrInvalidOperator, o)
+	}
+
+	return
+}
+
+// getComparisonFn will return the comparison function of the operator
+// Note: Non-matching relationship IDs do not stop iteration, comparisons are bound by the range of
+// the operator (see getRange) so the sentinel errors are reserved for custom comparison functions
+func (o Operator) getComparisonFn(value string) (fn ComparisonFn, err error) {
+	switch o {
+	case OperatorLessThan:
+		fn = func(relationshipID string) (ok bool, err error) {
+			ok = relationshipID < value
+			return
+		}
+	case OperatorLessThanOrEqualTo:
+		fn = func(relationshipID string) (ok bool, err error) {
+			ok = relationshipID <= value
+			return
+		}
+	case OperatorGreaterThan:
+		fn = func(relationshipID string) (ok bool, err error) {
+			ok = relationshipID > value
+			return
+		}
+	case OperatorGreaterThanOrEqualTo:
+		fn = func(relationshipID string) (ok bool, err error) {
+			ok = relationshipID >= value
+			return
+		}
+	case OperatorBetween:
+		// Range comparisons are bound by the range start and end
+		fn = nopComparisonFn
+
+	default:
+		err = o.Validate()
+	}
+
+	return
+}
+
+func (o Operator) getRange(value string) (rangeStart, rangeEnd string) {
+	switch o {
+	case OperatorLessThan, OperatorLessThanOrEqualTo:
+		rangeEnd = value
+	case OperatorGreaterThan, OperatorGreaterThanOrEqualTo:
+		rangeStart = value
+	}
+
+	return
+}
@@ -0,0 +1,14 @@
+package filters
+
+// Or creates a new or filter
+func Or(fs ...Filter) *OrFilter {
+	var o OrFilter
+	o.Filters = fs
+	return &o
+}
+
+// OrFilter will match entries which match any of the provided filters
+type OrFilter struct {
+	// Filters represents the filters to match against
+	Filters []Filter `json:"filters"`
+}
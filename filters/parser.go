@@ -0,0 +1,348 @@
+package filters
+
+import "fmt"
+
+func newParser(query string) (pp *parser, err error) {
+	var p parser
+	if p.tokens, err = lex(query); err != nil {
+		return
+	}
+
+	pp = &p
+	return
+}
+
+// parser is a recursive descent parser for filter queries
+//
+//	query      = list
+//	list       = unary { ( AND | OR ) unary }
+//	unary      = NOT "(" list ")" | NOT unary | "(" list ")" | HAS key | MISSING key | comparison
+//	comparison = key ( "=" | "!=" | "<" | "<=" | ">" | ">=" | PREFIX ) value
+//	           | key [ NOT ] IN "(" [ value { "," value } ] ")"
+//	           | key BETWEEN value AND value
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func (p *parser) peek() (t token) {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() (t token) {
+	t = p.tokens[p.index]
+	if t.typ != tokenEOF {
+		p.index++
+	}
+
+	return
+}
+
+func (p *parser) unexpected(t token, expected string) (err error) {
+	return fmt.Errorf("%w, expected %s and received %s at position %d", ErrInvalidQuery, expected, t, t.pos)
+}
+
+func (p *parser) expect(typ tokenType, expected string) (t token, err error) {
+	if t = p.next(); t.typ != typ {
+		err = p.unexpected(t, expected)
+	}
+
+	return
+}
+
+func (p *parser) expectKeyword(keyword string) (err error) {
+	if t := p.next(); !t.isKeyword(keyword) {
+		err = p.unexpected(t, keyword)
+	}
+
+	return
+}
+
+func (p *parser) parse() (fs []Filter, err error) {
+	if p.peek().typ == tokenEOF {
+		// Empty queries have no filters
+		return
+	}
+
+	var isOr bool
+	if fs, isOr, err = p.parseList(); err != nil {
+		return
+	}
+
+	if t := p.next(); t.typ != tokenEOF {
+		err = p.unexpected(t, "AND, OR or end of query")
+		return
+	}
+
+	if isOr {
+		fs = []Filter{Or(fs...)}
+	}
+
+	return
+}
+
+func (p *parser) parseList() (fs []Filter, isOr bool, err error) {
+	var f Filter
+	if f, err = p.parseUnary(); err != nil {
+		return
+	}
+
+	fs = append(fs, f)
+
+	var connector string
+	for {
+		t := p.peek()
+		var current string
+		switch {
+		case t.isKeyword(keywordAnd):
+			current = keywordAnd
+		case t.isKeyword(keywordOr):
+			current = keywordOr
+
+		default:
+			isOr = connector == keywordOr
+			return
+		}
+
+		if len(connector) > 0 && connector != current {
+			err = fmt.Errorf("%w (position %d)", ErrMixedOperators, t.pos)
+			return
+		}
+
+		connector = current
+		p.next()
+
+		if f, err = p.parseUnary(); err != nil {
+			return
+		}
+
+		fs = append(fs, f)
+	}
+}
+
+func (p *parser) parseUnary() (f Filter, err error) {
+	t := p.peek()
+	switch {
+	case t.isKeyword(keywordNot):
+		p.next()
+		if p.peek().typ == tokenLeftParen {
+			// Negated groups match all entries which do not match the group
+			p.next()
+			if f, err = p.parseGroup(); err != nil {
+				return
+			}
+
+			return Not(f), nil
+		}
+
+		if f, err = p.parseUnary(); err != nil {
+			return
+		}
+
+		return negate(f), nil
+	case t.typ == tokenLeftParen:
+		p.next()
+		return p.parseGroup()
+	case t.isKeyword(keywordHas):
+		p.next()
+		var key string
+		if key, err = p.parseKey(); err != nil {
+			return
+		}
+
+		return HasRelationship(key), nil
+	case t.isKeyword(keywordMissing):
+		p.next()
+		var key string
+		if key, err = p.parseKey(); err != nil {
+			return
+		}
+
+		return MissingRelationship(key), nil
+
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseGroup() (f Filter, err error) {
+	var (
+		fs   []Filter
+		isOr bool
+	)
+
+	if fs, isOr, err = p.parseList(); err != nil {
+		return
+	}
+
+	if _, err = p.expect(tokenRightParen, ")"); err != nil {
+		return
+	}
+
+	switch {
+	case len(fs) == 1:
+		return fs[0], nil
+	case isOr:
+		return Or(fs...), nil
+
+	default:
+		return And(fs...), nil
+	}
+}
+
+func (p *parser) parseKey() (key string, err error) {
+	t := p.next()
+	if t.typ != tokenIdent || isKeyword(t.value) {
+		err = p.unexpected(t, "relationship key")
+		return
+	}
+
+	key = t.value
+	return
+}
+
+func (p *parser) parseValue() (value string, err error) {
+	var t token
+	if t, err = p.expect(tokenString, "quoted value"); err != nil {
+		return
+	}
+
+	value = t.value
+	return
+}
+
+func (p *parser) parseComparison() (f Filter, err error) {
+	var key string
+	if key, err = p.parseKey(); err != nil {
+		return
+	}
+
+	t := p.next()
+	switch {
+	case t.typ == tokenOperator:
+		var value string
+		if value, err = p.parseValue(); err != nil {
+			return
+		}
+
+		return newFilterFromOperator(key, t.value, value), nil
+	case t.isKeyword(keywordPrefix):
+		var value string
+		if value, err = p.parseValue(); err != nil {
+			return
+		}
+
+		return Prefix(key, value), nil
+	case t.isKeyword(keywordIn):
+		var values []string
+		if values, err = p.parseValues(); err != nil {
+			return
+		}
+
+		return In(key, values...), nil
+	case t.isKeyword(keywordNot):
+		if err = p.expectKeyword(keywordIn); err != nil {
+			return
+		}
+
+		var values []string
+		if values, err = p.parseValues(); err != nil {
+			return
+		}
+
+		return NotIn(key, values...), nil
+	case t.isKeyword(keywordBetween):
+		return p.parseBetween(key)
+
+	default:
+		err = p.unexpected(t, "operator")
+		return
+	}
+}
+
+func (p *parser) parseValues() (values []string, err error) {
+	if _, err = p.expect(tokenLeftParen, "("); err != nil {
+		return
+	}
+
+	if p.peek().typ == tokenRightParen {
+		p.next()
+		return
+	}
+
+	for {
+		var value string
+		if value, err = p.parseValue(); err != nil {
+			return
+		}
+
+		values = append(values, value)
+
+		t := p.next()
+		switch t.typ {
+		case tokenComma:
+		case tokenRightParen:
+			return
+
+		default:
+			err = p.unexpected(t, ", or )")
+			return
+		}
+	}
+}
+
+func (p *parser) parseBetween(key string) (f Filter, err error) {
+	var rangeStart, rangeEnd string
+	if rangeStart, err = p.parseValue(); err != nil {
+		return
+	}
+
+	if err = p.expectKeyword(keywordAnd); err != nil {
+		return
+	}
+
+	if rangeEnd, err = p.parseValue(); err != nil {
+		return
+	}
+
+	return Range(key, rangeStart, rangeEnd), nil
+}
+
+func newFilterFromOperator(key, op, value string) (f Filter) {
+	switch op {
+	case "=":
+		return Match(key, value)
+	case "!=":
+		return InverseMatch(key, value)
+
+	default:
+		// Remaining operators have been validated by the lexer
+		return newOperatorComparison(key, Operator(op), value)
+	}
+}
+
+// negate will return the inverse of a filter
+// Note: Filters without an inverse form are wrapped with a not filter
+func negate(f Filter) (negated Filter) {
+	switch n := f.(type) {
+	case *MatchFilter:
+		return InverseMatch(n.RelationshipKey, n.RelationshipID)
+	case *InverseMatchFilter:
+		return Match(n.RelationshipKey, n.RelationshipID)
+	case *InFilter:
+		return NotIn(n.RelationshipKey, n.RelationshipIDs...)
+	case *NotInFilter:
+		return In(n.RelationshipKey, n.RelationshipIDs...)
+	case *HasRelationshipFilter:
+		return MissingRelationship(n.RelationshipKey)
+	case *MissingRelationshipFilter:
+		return HasRelationship(n.RelationshipKey)
+	case *NotFilter:
+		return n.Filter
+
+	default:
+		// Comparisons are not inverted by their operator, an entry with multiple relationship IDs
+		// can match both a comparison and its inverse
+		return Not(f)
+	}
+}
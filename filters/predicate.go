@@ -0,0 +1,38 @@
+package filters
+
+// Predicate creates a new predicate Filter
+// Note: Predicates are not indexed, they are applied to decoded entries after all indexed filters
+// have matched. Predicates cannot be nested within Or filters
+func Predicate(fn PredicateFn) *PredicateFilter {
+	var p PredicateFilter
+	p.Predicate = fn
+	return &p
+}
+
+// PredicateOf creates a new predicate Filter for entries of a given type
+// Note: Entries which are not of the provided type will not match
+func PredicateOf[T any](fn func(T) (bool, error)) *PredicateFilter {
+	return Predicate(func(val Value) (ok bool, err error) {
+		var v T
+		if v, ok = val.(T); !ok {
+			return
+		}
+
+		return fn(v)
+	})
+}
+
+// PredicateFilter will match decoded entries which satisfy the predicate
+type PredicateFilter struct {
+	// Predicate is not serializable and has no query representation
+	Predicate PredicateFn `json:"-"`
+}
+
+// PredicateFn is used for predicate filters
+type PredicateFn func(val Value) (ok bool, err error)
+
+// Value represents a decoded entry
+// Note: Values will be the entry type of the collection being filtered
+type Value interface {
+	GetID() string
+}
@@ -0,0 +1,17 @@
+package filters
+
+// Prefix creates a new prefix filter
+func Prefix(relationshipKey, prefix string) *PrefixFilter {
+	var p PrefixFilter
+	p.RelationshipKey = relationshipKey
+	p.Prefix = prefix
+	return &p
+}
+
+// PrefixFilter will match against a relationship key and any relationship ID which begins with the prefix
+type PrefixFilter struct {
+	// Relationship represents the relationship to target
+	RelationshipKey string `json:"relationshipKey"`
+	// Prefix represents the prefix of the corasponding relationship IDs
+	Prefix string `json:"prefix"`
+}
@@ -0,0 +1,96 @@
+package filters
+
+import (
+	"encoding/json"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// ErrInvalidQuery is returned when a query cannot be parsed
+	ErrInvalidQuery = errors.Error("invalid query")
+	// ErrInvalidOperator is returned when a comparison operator is not supported
+	ErrInvalidOperator = errors.Error("invalid operator")
+	// ErrMixedOperators is returned when AND and OR are combined without parentheses
+	ErrMixedOperators = errors.Error("invalid query, AND and OR cannot be combined without parentheses")
+	// ErrUnsupportedNegation is returned when negating an operator which has no inverse
+	ErrUnsupportedNegation = errors.Error("unsupported negation")
+	// ErrUnsupportedFilter is returned when formatting a filter which has no query representation
+	ErrUnsupportedFilter = errors.Error("unsupported filter")
+	// ErrUnserializableComparison is returned when a comparison filter was created with a custom comparison func
+	ErrUnserializableComparison = errors.Error("invalid comparison filter, comparisons with custom funcs cannot be serialized")
+)
+
+// Parse will parse a query into a set of filters
+// Example: status = "open" AND priority >= "3" AND NOT owner = "bob"
+func Parse(query string) (fs []Filter, err error) {
+	var p *parser
+	if p, err = newParser(query); err != nil {
+		return
+	}
+
+	return p.parse()
+}
+
+// Format will format a set of filters as a query
+func Format(fs ...Filter) (query string, err error) {
+	var f formatter
+	if err = f.formatAll(fs); err != nil {
+		return
+	}
+
+	query = f.String()
+	return
+}
+
+// Query represents a set of filters which is encoded as its text query
+type Query []Filter
+
+// String will return the text query
+// Note: An empty string is returned when the filters cannot be formatted
+func (q Query) String() (query string) {
+	query, _ = Format(q...)
+	return
+}
+
+// MarshalText is a text encoding helper func
+func (q Query) MarshalText() (text []byte, err error) {
+	var query string
+	if query, err = Format(q...); err != nil {
+		return
+	}
+
+	text = []byte(query)
+	return
+}
+
+// UnmarshalText is a text decoding helper func
+func (q *Query) UnmarshalText(text []byte) (err error) {
+	var fs []Filter
+	if fs, err = Parse(string(text)); err != nil {
+		return
+	}
+
+	*q = fs
+	return
+}
+
+// MarshalJSON is a JSON encoding helper func
+func (q Query) MarshalJSON() (bs []byte, err error) {
+	var text []byte
+	if text, err = q.MarshalText(); err != nil {
+		return
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON is a JSON decoding helper func
+func (q *Query) UnmarshalJSON(bs []byte) (err error) {
+	var query string
+	if err = json.Unmarshal(bs, &query); err != nil {
+		return
+	}
+
+	return q.UnmarshalText([]byte(query))
+}
@@ -0,0 +1,270 @@
+package filters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type testcase struct {
+		query    string
+		expected []Filter
+	}
+
+	tcs := []testcase{
+		{
+			query:    "",
+			expected: nil,
+		},
+		{
+			query:    `status = "open"`,
+			expected: []Filter{Match("status", "open")},
+		},
+		{
+			query: `status = "open" AND priority >= "3" AND NOT owner = "bob"`,
+			expected: []Filter{
+				Match("status", "open"),
+				GreaterThanOrEqualTo("priority", "3"),
+				InverseMatch("owner", "bob"),
+			},
+		},
+		{
+			query:    `status = "open" or status = "pending"`,
+			expected: []Filter{Or(Match("status", "open"), Match("status", "pending"))},
+		},
+		{
+			query: `(status = "open" OR owner != "bob") AND team PREFIX "org:1:"`,
+			expected: []Filter{
+				Or(Match("status", "open"), InverseMatch("owner", "bob")),
+				Prefix("team", "org:1:"),
+			},
+		},
+		{
+			query: `status IN ("open", "pending") AND owner NOT IN ("bob") AND NOT tag IN ()`,
+			expected: []Filter{
+				In("status", "open", "pending"),
+				NotIn("owner", "bob"),
+				NotIn("tag"),
+			},
+		},
+		{
+			query:    `HAS owner AND NOT HAS team AND NOT MISSING status`,
+			expected: []Filter{HasRelationship("owner"), MissingRelationship("team"), HasRelationship("status")},
+		},
+		{
+			query:    `created BETWEEN "2020" AND "2021" AND NOT priority < "3"`,
+			expected: []Filter{Range("created", "2020", "2021"), Not(LessThan("priority", "3"))},
+		},
+		{
+			query:    `name = "say \"hi\""`,
+			expected: []Filter{Match("name", `say "hi"`)},
+		},
+		{
+			query: `(status = "open" OR owner = "bob") AND NOT (team = "a" AND tag = "b")`,
+			expected: []Filter{
+				Or(Match("status", "open"), Match("owner", "bob")),
+				Not(And(Match("team", "a"), Match("tag", "b"))),
+			},
+		},
+		{
+			query:    `status = "open" OR (owner = "bob" AND NOT team PREFIX "org")`,
+			expected: []Filter{Or(Match("status", "open"), And(Match("owner", "bob"), Not(Prefix("team", "org"))))},
+		},
+		{
+			query:    `NOT (status = "open") AND NOT NOT (owner = "bob")`,
+			expected: []Filter{Not(Match("status", "open")), Match("owner", "bob")},
+		},
+	}
+
+	for _, tc := range tcs {
+		fs, err := Parse(tc.query)
+		if err != nil {
+			t.Fatalf("error parsing <%s>: %v", tc.query, err)
+		}
+
+		if err = testCompareFilters(tc.expected, fs); err != nil {
+			t.Fatalf("invalid filters for <%s>: %v", tc.query, err)
+		}
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	type testcase struct {
+		query    string
+		expected error
+	}
+
+	tcs := []testcase{
+		{query: `status = "open" AND owner = "bob" OR team = "a"`, expected: ErrMixedOperators},
+		{query: `status = open`, expected: ErrInvalidQuery},
+		{query: `status = "open`, expected: ErrInvalidQuery},
+		{query: `status == "open"`, expected: ErrInvalidQuery},
+		{query: `(status = "open"`, expected: ErrInvalidQuery},
+		{query: `(status = "open" AND owner = "bob" OR team = "a")`, expected: ErrMixedOperators},
+		{query: `NOT (status = "open"`, expected: ErrInvalidQuery},
+		{query: `status = "open" owner = "bob"`, expected: ErrInvalidQuery},
+		{query: `AND = "open"`, expected: ErrInvalidQuery},
+	}
+
+	for _, tc := range tcs {
+		if _, err := Parse(tc.query); !errors.Is(err, tc.expected) {
+			t.Fatalf("invalid error for <%s>, expected %v and received %v", tc.query, tc.expected, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	type testcase struct {
+		filters  []Filter
+		expected string
+	}
+
+	tcs := []testcase{
+		{
+			filters:  []Filter{Match("status", "open"), GreaterThanOrEqualTo("priority", "3"), InverseMatch("owner", "bob")},
+			expected: `status = "open" AND priority >= "3" AND owner != "bob"`,
+		},
+		{
+			filters:  []Filter{Or(Match("status", "open"), In("tag", "a", "b")), NotIn("owner", "bob")},
+			expected: `(status = "open" OR tag IN ("a", "b")) AND owner NOT IN ("bob")`,
+		},
+		{
+			filters:  []Filter{Range("created", "2020", "2021"), Prefix("team", "org:1:"), HasRelationship("owner"), MissingRelationship("team")},
+			expected: `created BETWEEN "2020" AND "2021" AND team PREFIX "org:1:" AND HAS owner AND MISSING team`,
+		},
+		{
+			filters:  []Filter{Or(Match("status", "open"), And(Match("owner", "bob"), Match("team", "a"))), Not(Match("tag", "b"))},
+			expected: `(status = "open" OR (owner = "bob" AND team = "a")) AND NOT (tag = "b")`,
+		},
+		{
+			filters:  []Filter{Not(Or(Match("status", "open"), Range("created", "2020", "2021")))},
+			expected: `NOT (status = "open" OR created BETWEEN "2020" AND "2021")`,
+		},
+	}
+
+	for _, tc := range tcs {
+		query, err := Format(tc.filters...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if query != tc.expected {
+			t.Fatalf("invalid query, expected <%s> and received <%s>", tc.expected, query)
+		}
+
+		var parsed []Filter
+		if parsed, err = Parse(query); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = testCompareFilters(tc.filters, parsed); err != nil {
+			t.Fatalf("invalid round trip for <%s>: %v", query, err)
+		}
+	}
+}
+
+func TestFormat_errors(t *testing.T) {
+	fn := func(relationshipID string) (ok bool, err error) { return }
+	if _, err := Format(Comparison("status", fn)); !errors.Is(err, ErrUnserializableComparison) {
+		t.Fatalf("invalid error, expected %v and received %v", ErrUnserializableComparison, err)
+	}
+
+	if _, err := Format(Match("has space", "open")); !errors.Is(err, ErrUnsupportedFilter) {
+		t.Fatalf("invalid error, expected %v and received %v", ErrUnsupportedFilter, err)
+	}
+}
+
+func TestQuery_JSON(t *testing.T) {
+	type savedSearch struct {
+		Name  string `json:"name"`
+		Query Query  `json:"query"`
+	}
+
+	var s savedSearch
+	s.Name = "open tickets"
+	s.Query = Query{Match("status", "open"), LessThan("priority", "3")}
+
+	bs, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"name":"open tickets","query":"status = \"open\" AND priority \u003c \"3\""}`
+	if string(bs) != expected {
+		t.Fatalf("invalid JSON, expected %s and received %s", expected, bs)
+	}
+
+	var decoded savedSearch
+	if err = json.Unmarshal(bs, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCompareFilters(s.Query, decoded.Query); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestComparisonFilter_JSON(t *testing.T) {
+	bs, err := json.Marshal(GreaterThan("priority", "3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded ComparisonFilter
+	if err = json.Unmarshal(bs, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCompareFilters([]Filter{GreaterThan("priority", "3")}, []Filter{&decoded}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ok bool
+	if ok, err = decoded.Comparison("4"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatal("expected decoded comparison to match <4>")
+	}
+
+	fn := func(relationshipID string) (ok bool, err error) { return }
+	if bs, err = json.Marshal(Comparison("priority", fn)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(bs, &decoded); !errors.Is(err, ErrUnserializableComparison) {
+		t.Fatalf("invalid error, expected %v and received %v", ErrUnserializableComparison, err)
+	}
+}
+
+// testCompareFilters compares filters by their types and encoded values, comparison funcs cannot be compared directly
+func testCompareFilters(expected, received []Filter) (err error) {
+	if len(expected) != len(received) {
+		return fmt.Errorf("expected %d filters and received %d", len(expected), len(received))
+	}
+
+	for i := range expected {
+		if a, b := fmt.Sprintf("%T", expected[i]), fmt.Sprintf("%T", received[i]); a != b {
+			return errors.New("expected type " + a + " and received " + b)
+		}
+	}
+
+	var a, b []byte
+	if a, err = json.Marshal(expected); err != nil {
+		return
+	}
+
+	if b, err = json.Marshal(received); err != nil {
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		return errors.New("expected " + string(a) + " and received " + string(b))
+	}
+
+	return
+}
@@ -0,0 +1,27 @@
+package filters
+
+// HasRelationship creates a new has relationship filter
+func HasRelationship(relationshipKey string) *HasRelationshipFilter {
+	var h HasRelationshipFilter
+	h.RelationshipKey = relationshipKey
+	return &h
+}
+
+// HasRelationshipFilter will match entries which have at least one relationship ID for a relationship key
+type HasRelationshipFilter struct {
+	// Relationship represents the relationship to target
+	RelationshipKey string `json:"relationshipKey"`
+}
+
+// MissingRelationship creates a new missing relationship filter
+func MissingRelationship(relationshipKey string) *MissingRelationshipFilter {
+	var m MissingRelationshipFilter
+	m.RelationshipKey = relationshipKey
+	return &m
+}
+
+// MissingRelationshipFilter will match entries which have no relationship IDs for a relationship key
+type MissingRelationshipFilter struct {
+	// Relationship represents the relationship to target
+	RelationshipKey string `json:"relationshipKey"`
+}
@@ -0,0 +1,18 @@
+package filters
+
+// TextKey is the relationship key of the text index
+const TextKey = "_text"
+
+// Text creates a new text Filter
+// Note: Entries match when their searchable fields contain every term of the query. Requires the
+// text index to be enabled (see mojura.Opts.IndexText)
+func Text(query string) *TextFilter {
+	var t TextFilter
+	t.Query = query
+	return &t
+}
+
+// TextFilter will match entries whose searchable fields contain every term of the query
+type TextFilter struct {
+	Query string `json:"query"`
+}
@@ -0,0 +1,22 @@
+package filters
+
+import "time"
+
+const (
+	// CreatedAtKey is the relationship key of the created at timestamp index
+	CreatedAtKey = "_createdAt"
+	// UpdatedAtKey is the relationship key of the updated at timestamp index
+	UpdatedAtKey = "_updatedAt"
+)
+
+// CreatedBetween will match entries created within the provided range (inclusive)
+// Note: Requires timestamp indexes to be enabled (see mojura.Opts.IndexTimestamps)
+func CreatedBetween(start, end time.Time) *ComparisonFilter {
+	return RangeTyped(CreatedAtKey, start.Unix(), end.Unix())
+}
+
+// UpdatedSince will match entries updated at or after the provided time
+// Note: Requires timestamp indexes to be enabled (see mojura.Opts.IndexTimestamps)
+func UpdatedSince(since time.Time) *ComparisonFilter {
+	return GreaterThanOrEqualToTyped(UpdatedAtKey, since.Unix())
+}
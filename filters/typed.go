@@ -0,0 +1,178 @@
+package filters
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// ErrInvalidEncoding is returned when a relationship ID is not a valid typed encoding
+	ErrInvalidEncoding = errors.Error("invalid encoding, relationship ID does not match the expected type")
+)
+
+const signBit = uint64(1) << 63
+
+// Typed represents the relationship types which have an order-preserving encoding
+// Note: int is encoded as an int64, so both types are comparable with one another
+type Typed interface {
+	int | int64 | uint64 | float64 | time.Time | bool
+}
+
+// Encode will encode a typed value as an order-preserving relationship ID
+// Note: Encoded relationship IDs are hex encoded so they remain printable within queries
+func Encode[T Typed](value T) (relationshipID string) {
+	var bs []byte
+	switch v := interface{}(value).(type) {
+	case int:
+		bs = encodeUint64(uint64(v) ^ signBit)
+	case int64:
+		bs = encodeUint64(uint64(v) ^ signBit)
+	case uint64:
+		bs = encodeUint64(v)
+	case float64:
+		bs = encodeUint64(encodeFloat64Bits(v))
+	case time.Time:
+		// Seconds are followed by nanoseconds so the full range of time.Time is supported
+		bs = encodeUint64(uint64(v.Unix()) ^ signBit)
+		bs = bs[:12]
+		binary.BigEndian.PutUint32(bs[8:], uint32(v.Nanosecond()))
+	case bool:
+		bs = []byte{0}
+		if v {
+			bs[0] = 1
+		}
+	}
+
+	return hex.EncodeToString(bs)
+}
+
+// Decode will decode an order-preserving relationship ID as a typed value
+func Decode[T Typed](relationshipID string) (value T, err error) {
+	var bs []byte
+	if bs, err = hex.DecodeString(relationshipID); err != nil {
+		err = ErrInvalidEncoding
+		return
+	}
+
+	switch v := interface{}(&value).(type) {
+	case *int:
+		var u uint64
+		u, err = decodeUint64(bs)
+		*v = int(int64(u ^ signBit))
+	case *int64:
+		var u uint64
+		u, err = decodeUint64(bs)
+		*v = int64(u ^ signBit)
+	case *uint64:
+		*v, err = decodeUint64(bs)
+	case *float64:
+		var u uint64
+		u, err = decodeUint64(bs)
+		*v = decodeFloat64Bits(u)
+	case *time.Time:
+		if len(bs) != 12 {
+			err = ErrInvalidEncoding
+			return
+		}
+
+		var u uint64
+		u, err = decodeUint64(bs[:8])
+		*v = time.Unix(int64(u^signBit), int64(binary.BigEndian.Uint32(bs[8:])))
+	case *bool:
+		if len(bs) != 1 || bs[0] > 1 {
+			err = ErrInvalidEncoding
+			return
+		}
+
+		*v = bs[0] == 1
+	}
+
+	return
+}
+
+// MatchTyped creates a new match filter for a typed value
+func MatchTyped[T Typed](relationshipKey string, value T) *MatchFilter {
+	return Match(relationshipKey, Encode(value))
+}
+
+// InTyped creates a new in filter for a set of typed values
+func InTyped[T Typed](relationshipKey string, values ...T) *InFilter {
+	return In(relationshipKey, encodeAll(values)...)
+}
+
+// LessThanTyped is an alias func for a less than comparison of a typed value
+func LessThanTyped[T Typed](relationshipKey string, lessThan T) *ComparisonFilter {
+	return LessThan(relationshipKey, Encode(lessThan))
+}
+
+// LessThanOrEqualToTyped is an alias func for a less than or equal to comparison of a typed value
+func LessThanOrEqualToTyped[T Typed](relationshipKey string, lessThanOrEqualTo T) *ComparisonFilter {
+	return LessThanOrEqualTo(relationshipKey, Encode(lessThanOrEqualTo))
+}
+
+// GreaterThanTyped is an alias func for a greater than comparison of a typed value
+func GreaterThanTyped[T Typed](relationshipKey string, greaterThan T) *ComparisonFilter {
+	return GreaterThan(relationshipKey, Encode(greaterThan))
+}
+
+// GreaterThanOrEqualToTyped is an alias func for a greater than or equal to comparison of a typed value
+func GreaterThanOrEqualToTyped[T Typed](relationshipKey string, greaterThanOrEqualTo T) *ComparisonFilter {
+	return GreaterThanOrEqualTo(relationshipKey, Encode(greaterThanOrEqualTo))
+}
+
+// RangeTyped is an alias func for range comparison of typed values
+func RangeTyped[T Typed](relationshipKey string, rangeStart, rangeEnd T) *ComparisonFilter {
+	return Range(relationshipKey, Encode(rangeStart), Encode(rangeEnd))
+}
+
+func encodeAll[T Typed](values []T) (relationshipIDs []string) {
+	relationshipIDs = make([]string, 0, len(values))
+	for _, value := range values {
+		relationshipIDs = append(relationshipIDs, Encode(value))
+	}
+
+	return
+}
+
+func encodeUint64(u uint64) (bs []byte) {
+	bs = make([]byte, 8, 12)
+	binary.BigEndian.PutUint64(bs, u)
+	return
+}
+
+func decodeUint64(bs []byte) (u uint64, err error) {
+	if len(bs) != 8 {
+		err = ErrInvalidEncoding
+		return
+	}
+
+	u = binary.BigEndian.Uint64(bs)
+	return
+}
+
+func encodeFloat64Bits(f float64) (u uint64) {
+	if f == 0 {
+		// Negative zero is equal to zero, canonicalize it so both share an encoding
+		f = 0
+	}
+
+	u = math.Float64bits(f)
+	if u&signBit != 0 {
+		// Negative values are inverted so larger magnitudes sort first
+		return ^u
+	}
+
+	return u | signBit
+}
+
+func decodeFloat64Bits(u uint64) (f float64) {
+	if u&signBit != 0 {
+		return math.Float64frombits(u &^ signBit)
+	}
+
+	return math.Float64frombits(^u)
+}
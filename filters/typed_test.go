@@ -0,0 +1,108 @@
+package filters
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestEncode_order(t *testing.T) {
+	testEncodeOrder(t, []int64{math.MinInt64, -100, -9, -1, 0, 1, 9, 10, 100, math.MaxInt64})
+	testEncodeOrder(t, []int{-10, -9, 0, 9, 10})
+	testEncodeOrder(t, []uint64{0, 1, 9, 10, 100, math.MaxUint64})
+	testEncodeOrder(t, []float64{math.Inf(-1), -100.5, -1.25, -0.5, 0, 0.5, 1.25, 9, 10, math.Inf(1)})
+	testEncodeOrder(t, []bool{false, true})
+
+	now := time.Now()
+	testEncodeOrder(t, []time.Time{
+		time.Date(1200, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(0, 0),
+		now,
+		now.Add(time.Nanosecond),
+		now.Add(time.Second),
+		time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestEncode_negative_zero(t *testing.T) {
+	negativeZero := math.Copysign(0, -1)
+	if Encode(negativeZero) != Encode(0.0) {
+		t.Fatalf("invalid encoding, expected <%s> and received <%s>", Encode(0.0), Encode(negativeZero))
+	}
+
+	decoded, err := Decode[float64](Encode(negativeZero))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Signbit(decoded) {
+		t.Fatalf("invalid value, expected positive zero and received %v", decoded)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testDecode(t, int64(-42))
+	testDecode(t, 42)
+	testDecode(t, uint64(math.MaxUint64))
+	testDecode(t, -1.25)
+	testDecode(t, true)
+
+	ts := time.Date(1969, 7, 20, 20, 17, 0, 42, time.UTC)
+	decoded, err := Decode[time.Time](Encode(ts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Equal(ts) {
+		t.Fatalf("invalid value, expected %v and received %v", ts, decoded)
+	}
+
+	if _, err = Decode[int64](Encode(true)); err != ErrInvalidEncoding {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidEncoding, err)
+	}
+
+	if _, err = Decode[int64]("not hex"); err != ErrInvalidEncoding {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidEncoding, err)
+	}
+}
+
+func TestGreaterThanTyped(t *testing.T) {
+	f := GreaterThanTyped("price", 9)
+	for _, tc := range []struct {
+		value    int
+		expected bool
+	}{
+		{value: -10, expected: false},
+		{value: 9, expected: false},
+		{value: 10, expected: true},
+		{value: 100, expected: true},
+	} {
+		ok, err := f.Comparison(Encode(tc.value))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ok != tc.expected {
+			t.Fatalf("invalid comparison for %d, expected %v and received %v", tc.value, tc.expected, ok)
+		}
+	}
+}
+
+func testEncodeOrder[T Typed](t *testing.T, ordered []T) {
+	encoded := encodeAll(ordered)
+	if !sort.StringsAreSorted(encoded) {
+		t.Fatalf("invalid order, expected encoding of %v to be sorted and received %v", ordered, encoded)
+	}
+}
+
+func testDecode[T int | int64 | uint64 | float64 | bool](t *testing.T, value T) {
+	decoded, err := Decode[T](Encode(value))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != value {
+		t.Fatalf("invalid value, expected %v and received %v", value, decoded)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/mojura/mojura
 
-go 1.14
+go 1.18
 
 require (
 	github.com/gdbu/actions v0.6.0
@@ -10,4 +10,12 @@ require (
 	github.com/hatchify/errors v0.4.82
 	github.com/mojura-backends/bolt v0.2.0
 	github.com/mojura/backend v0.2.0
+	golang.org/x/text v0.14.0
+)
+
+require (
+	github.com/edsrzf/mmap-go v1.0.0 // indirect
+	github.com/gdbu/bolt v1.4.0 // indirect
+	github.com/gdbu/logger v0.6.2 // indirect
+	golang.org/x/sys v0.5.0 // indirect
 )
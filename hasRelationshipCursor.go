@@ -0,0 +1,197 @@
+package mojura
+
+import (
+	"bytes"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+var _ filterCursor = &hasRelationshipCursor{}
+
+func newMissingRelationshipCursor(txn *Transaction, f *filters.MissingRelationshipFilter) (c filterCursor, err error) {
+	var bkt backend.Bucket
+	if bkt, err = txn.getMissingBucket([]byte(f.RelationshipKey)); err != nil {
+		return
+	}
+
+	var match matchCursor
+	match.txn = txn
+	match.cur = bkt.Cursor()
+	c = &match
+	return
+}
+
+func newHasRelationshipCursor(txn *Transaction, f *filters.HasRelationshipFilter) (cur *hasRelationshipCursor, err error) {
+	var c hasRelationshipCursor
+	var missingBkt backend.Bucket
+	if missingBkt, err = txn.getMissingBucket([]byte(f.RelationshipKey)); err != nil {
+		return
+	}
+
+	var entriesBkt backend.Bucket
+	if entriesBkt, err = txn.getEntriesBucket(); err != nil {
+		return
+	}
+
+	c.txn = txn
+	c.cur = entriesBkt.Cursor()
+	c.missingCur = missingBkt.Cursor()
+	cur = &c
+	return
+}
+
+// newEntriesFilterCursor will return a filter cursor which iterates through all entries
+func newEntriesFilterCursor(txn *Transaction) (cur *hasRelationshipCursor, err error) {
+	var c hasRelationshipCursor
+	var entriesBkt backend.Bucket
+	if entriesBkt, err = txn.getEntriesBucket(); err != nil {
+		return
+	}
+
+	c.txn = txn
+	c.cur = entriesBkt.Cursor()
+	cur = &c
+	return
+}
+
+// hasRelationshipCursor iterates through all entries which are not within the missing index of a relationship key
+// Note: When the missing cursor is unset, all entries are iterated
+type hasRelationshipCursor struct {
+	txn *Transaction
+
+	cur        backend.Cursor
+	missingCur backend.Cursor
+}
+
+func (c *hasRelationshipCursor) isMissing(entryID []byte) (missing bool) {
+	if c.missingCur == nil {
+		return false
+	}
+
+	// Get the first key matching entryID (will get next key if entryID does not exist)
+	firstKey, _ := c.missingCur.Seek(entryID)
+	// If the first key matches the entry ID, the entry is missing the relationship
+	return bytes.Equal(entryID, firstKey)
+}
+
+func (c *hasRelationshipCursor) nextUntilMatch(entryID []byte) (matchingEntryID []byte, err error) {
+	for entryID != nil && c.isMissing(entryID) {
+		entryID, _ = c.cur.Next()
+	}
+
+	if entryID == nil {
+		err = Break
+		return
+	}
+
+	matchingEntryID = entryID
+	return
+}
+
+func (c *hasRelationshipCursor) prevUntilMatch(entryID []byte) (matchingEntryID []byte, err error) {
+	for entryID != nil && c.isMissing(entryID) {
+		entryID, _ = c.cur.Prev()
+	}
+
+	if entryID == nil {
+		err = Break
+		return
+	}
+
+	matchingEntryID = entryID
+	return
+}
+
+func (c *hasRelationshipCursor) has(entryID []byte) (ok bool, err error) {
+	// Get the first key matching entryID (will get next key if entryID does not exist)
+	firstKey, _ := c.cur.Seek(entryID)
+	if !bytes.Equal(entryID, firstKey) {
+		return
+	}
+
+	ok = !c.isMissing(entryID)
+	return
+}
+
+func (c *hasRelationshipCursor) getCurrentRelationshipID() (relationshipID string) {
+	return ""
+}
+
+// SeekForward will seek the provided ID in a forward direction
+func (c *hasRelationshipCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	entryID, _ = c.cur.Seek(seekID)
+	return c.nextUntilMatch(entryID)
+}
+
+// SeekReverse will seek the provided ID in a reverse direction
+func (c *hasRelationshipCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	entryID, _ = c.cur.Seek(seekID)
+	return c.prevUntilMatch(entryID)
+}
+
+// First will return the first entry
+func (c *hasRelationshipCursor) First() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	entryID, _ = c.cur.First()
+	return c.nextUntilMatch(entryID)
+}
+
+// Last will return the last entry
+func (c *hasRelationshipCursor) Last() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	entryID, _ = c.cur.Last()
+	return c.prevUntilMatch(entryID)
+}
+
+// Next will return the next entry
+func (c *hasRelationshipCursor) Next() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	entryID, _ = c.cur.Next()
+	return c.nextUntilMatch(entryID)
+}
+
+// Prev will return the previous entry
+func (c *hasRelationshipCursor) Prev() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	entryID, _ = c.cur.Prev()
+	return c.prevUntilMatch(entryID)
+}
+
+// HasForward will determine if an entry exists in a forward direction
+func (c *hasRelationshipCursor) HasForward(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.has(entryID)
+}
+
+// HasReverse will determine if an entry exists in a reverse direction
+func (c *hasRelationshipCursor) HasReverse(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.has(entryID)
+}
@@ -0,0 +1,169 @@
+package mojura
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+func Test_hasRelationshipCursor(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "", "group_0", "0"),
+		newTestStruct("user_1", "contact_1", "group_1", "1"),
+		newTestStruct("", "", "group_1", "2"),
+		newTestStruct("user_1", "contact_1", "group_0", "3"),
+		newTestStruct("", "contact_0", "group_1", "4", "tag_0", "tag_1"),
+	}
+
+	for _, entry := range entries {
+		if entry.ID, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters:  []Filter{filters.HasRelationship("users")},
+			expected: []string{"00000000", "00000001", "00000003"},
+		},
+		{
+			filters:  []Filter{filters.HasRelationship("users")},
+			reverse:  true,
+			expected: []string{"00000003", "00000001", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.MissingRelationship("users")},
+			expected: []string{"00000002", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.MissingRelationship("tags")},
+			reverse:  true,
+			expected: []string{"00000003", "00000002", "00000001", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.HasRelationship("tags")},
+			expected: []string{"00000004"},
+		},
+		{
+			filters:  []Filter{filters.Match("groups", "group_1"), filters.MissingRelationship("contacts")},
+			expected: []string{"00000002"},
+		},
+		{
+			filters:  []Filter{filters.Match("groups", "group_1"), filters.HasRelationship("contacts")},
+			expected: []string{"00000001", "00000004"},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+
+	// Assign a user to an unassigned entry
+	entries[2].UserID = "user_2"
+	if err = m.Edit(entries[2].ID, entries[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unassign the user of an assigned entry
+	entries[3].UserID = ""
+	if err = m.Edit(entries[3].ID, entries[3]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Remove(entries[4].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tcs = []testFilteredCase{
+		{
+			filters:  []Filter{filters.HasRelationship("users")},
+			expected: []string{"00000000", "00000001", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.MissingRelationship("users")},
+			expected: []string{"00000003"},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
+func Test_hasRelationshipCursor_backfill(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { testTeardown(m) }()
+
+	if _, err = m.New(newTestStruct("user_0", "", "group_0", "0")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = m.New(newTestStruct("user_1", "contact_1", "group_1", "1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the missing index to simulate a DB which was created before the index existed
+	if err = m.db.Transaction(func(txn backend.Transaction) (err error) {
+		return txn.GetBucket(missingBktKey).DeleteBucket([]byte("contacts"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.MissingRelationship("contacts")},
+			expected: []string{"00000000"},
+		},
+	})
+
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		var cur *hasRelationshipCursor
+		if cur, err = newHasRelationshipCursor(txn, filters.HasRelationship("contacts")); err != nil {
+			return
+		}
+
+		var ok bool
+		if ok, err = cur.HasForward([]byte("00000001")); err != nil {
+			return
+		}
+
+		if !ok {
+			t.Fatal("expected entry <00000001> to have a contact relationship")
+		}
+
+		if ok, err = cur.HasForward([]byte("00000000")); err != nil {
+			return
+		}
+
+		if ok {
+			t.Fatal("expected entry <00000000> to be missing a contact relationship")
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
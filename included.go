@@ -0,0 +1,30 @@
+package mojura
+
+// Included represents eagerly loaded related entries. Entries are keyed by relationship key and then by entry ID
+type Included map[string]map[string]Value
+
+// Get will get an included entry by relationship key and entry ID
+func (i Included) Get(relationshipKey, entryID string) (val Value, ok bool) {
+	var entries map[string]Value
+	if entries, ok = i[relationshipKey]; !ok {
+		return
+	}
+
+	val, ok = entries[entryID]
+	return
+}
+
+func (i Included) set(relationshipKey, entryID string, val Value) {
+	entries, ok := i[relationshipKey]
+	if !ok {
+		entries = make(map[string]Value)
+		i[relationshipKey] = entries
+	}
+
+	entries[entryID] = val
+}
+
+func (i Included) has(relationshipKey, entryID string) (ok bool) {
+	_, ok = i.Get(relationshipKey, entryID)
+	return
+}
@@ -12,17 +12,27 @@ var (
 )
 
 func newInverseMatchCursor(txn *Transaction, f *filters.InverseMatchFilter) (cur *inverseMatchCursor, err error) {
+	return newInverseCursor(txn, f.RelationshipKey, f.RelationshipID)
+}
+
+func newNotInCursor(txn *Transaction, f *filters.NotInFilter) (cur *inverseMatchCursor, err error) {
+	return newInverseCursor(txn, f.RelationshipKey, f.RelationshipIDs...)
+}
+
+func newInverseCursor(txn *Transaction, relationshipKey string, relationshipIDs ...string) (cur *inverseMatchCursor, err error) {
 	var c inverseMatchCursor
-	if c.parent, err = txn.getRelationshipBucket([]byte(f.RelationshipKey)); err != nil {
+	if c.parent, err = txn.getRelationshipBucket([]byte(relationshipKey)); err != nil {
 		return
 	}
 
 	c.txn = txn
 	c.bktCur = c.parent.Cursor()
-	c.targetRelationshipID = []byte(f.RelationshipID)
 
-	if matchBkt := c.parent.GetBucket([]byte(f.RelationshipID)); matchBkt != nil {
-		c.matchCur = matchBkt.Cursor()
+	for _, relationshipID := range relationshipIDs {
+		c.targetRelationshipIDs = append(c.targetRelationshipIDs, []byte(relationshipID))
+		if matchBkt := c.parent.GetBucket([]byte(relationshipID)); matchBkt != nil {
+			c.matchCurs = append(c.matchCurs, matchBkt.Cursor())
+		}
 	}
 
 	cur = &c
@@ -32,28 +42,44 @@ func newInverseMatchCursor(txn *Transaction, f *filters.InverseMatchFilter) (cur
 type inverseMatchCursor struct {
 	txn *Transaction
 
-	parent   backend.Bucket
-	bktCur   backend.Cursor
-	cur      backend.Cursor
-	matchCur backend.Cursor
+	parent backend.Bucket
+	bktCur backend.Cursor
+	cur    backend.Cursor
+	// Cursors for each of the target buckets which exist
+	matchCurs []backend.Cursor
 
-	targetRelationshipID  []byte
+	targetRelationshipIDs [][]byte
 	currentRelationshipID []byte
 }
 
+func (c *inverseMatchCursor) isTarget(relationshipID []byte) (ok bool) {
+	if relationshipID == nil {
+		return false
+	}
+
+	for _, targetRelationshipID := range c.targetRelationshipIDs {
+		if bytes.Equal(relationshipID, targetRelationshipID) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *inverseMatchCursor) has(entryID []byte) (ok bool, err error) {
-	if c.matchCur == nil {
-		// If match cursor does not exist, that means that the target bucket does not exist.
-		// Because this cursor is an inverse cursor, this means that all values will not match
-		// this relationship key and we can automatically return true
-		return true, nil
+	// Note: If no match cursors exist, that means that none of the target buckets exist.
+	// Because this cursor is an inverse cursor, this means that all values will not match
+	// this relationship key and we can automatically return true
+	for _, matchCur := range c.matchCurs {
+		// Get the first key matching entryID (will get next key if entryID does not exist)
+		firstKey, _ := matchCur.Seek(entryID)
+		// If the first key matches the entry ID, the entry belongs to a target bucket
+		if bytes.Equal(entryID, firstKey) {
+			return false, nil
+		}
 	}
 
-	// Get the first key matching entryID (will get next key if entryID does not exist)
-	firstKey, _ := c.matchCur.Seek(entryID)
-	// If the first key matches the entry ID, we have a match
-	ok = !bytes.Equal(entryID, firstKey)
-	return
+	return true, nil
 }
 
 func (c *inverseMatchCursor) getCurrentRelationshipID() (relationshipID string) {
@@ -91,11 +117,14 @@ func (c *inverseMatchCursor) nextBucket() (bktKey []byte, err error) {
 
 	for {
 		bktKey, _ = fn()
+		// Ensure subsequent iterations continue from the current bucket
+		fn = c.bktCur.Next
 		switch {
-		case bytes.Equal(bktKey, c.targetRelationshipID):
 		case bktKey == nil:
 			err = Break
 			return
+		case c.isTarget(bktKey):
+			// Target buckets are skipped
 
 		default:
 			return
@@ -109,7 +138,7 @@ func (c *inverseMatchCursor) setNextAvailableCursor() (err error) {
 			return
 		}
 
-		if !bytes.Equal(c.targetRelationshipID, c.currentRelationshipID) {
+		if !c.isTarget(c.currentRelationshipID) {
 			return
 		}
 	}
@@ -163,11 +192,14 @@ func (c *inverseMatchCursor) prevBucket() (bktKey []byte, err error) {
 
 	for {
 		bktKey, _ = fn()
+		// Ensure subsequent iterations continue from the current bucket
+		fn = c.bktCur.Prev
 		switch {
-		case bytes.Equal(bktKey, c.targetRelationshipID):
 		case bktKey == nil:
 			err = Break
 			return
+		case c.isTarget(bktKey):
+			// Target buckets are skipped
 
 		default:
 			return
@@ -181,7 +213,7 @@ func (c *inverseMatchCursor) setPrevAvailableCursor() (err error) {
 			return
 		}
 
-		if !bytes.Equal(c.targetRelationshipID, c.currentRelationshipID) {
+		if !c.isTarget(c.currentRelationshipID) {
 			return
 		}
 	}
@@ -216,7 +248,7 @@ func (c *inverseMatchCursor) firstBktKey() (bktKey []byte, err error) {
 		case bktKey == nil:
 			err = Break
 			return
-		case !bytes.Equal(c.targetRelationshipID, bktKey):
+		case !c.isTarget(bktKey):
 			return
 
 		default:
@@ -250,11 +282,11 @@ func (c *inverseMatchCursor) lastBktKey() (bktKey []byte, err error) {
 		case bktKey == nil:
 			err = Break
 			return
-		case !bytes.Equal(c.targetRelationshipID, bktKey):
+		case !c.isTarget(bktKey):
 			return
 
 		default:
-			bktKey, _ = c.bktCur.Next()
+			bktKey, _ = c.bktCur.Prev()
 		}
 	}
 }
@@ -269,7 +301,7 @@ func (c *inverseMatchCursor) last() (entryID []byte, err error) {
 		return
 	}
 
-	if bytes.Equal(c.targetRelationshipID, c.currentRelationshipID) {
+	if c.isTarget(c.currentRelationshipID) {
 		if err = c.setPrevCursor(); err != nil {
 			return
 		}
@@ -305,12 +337,7 @@ func (c *inverseMatchCursor) setCursor(relationshipID []byte) (err error) {
 	return
 }
 
-// SeekForward will seek the provided ID in a forward direction
-func (c *inverseMatchCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
-	if err = c.txn.cc.isDone(); err != nil {
-		return
-	}
-
+func (c *inverseMatchCursor) seekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
 	if err = c.setCursor(relationshipID); err != nil {
 		return
 	}
@@ -325,7 +352,7 @@ func (c *inverseMatchCursor) SeekForward(relationshipID, seekID []byte) (entryID
 		entryID, _ = c.cur.Seek([]byte(seekID))
 		switch {
 		case entryID == nil:
-		case bytes.Equal(c.targetRelationshipID, c.currentRelationshipID):
+		case c.isTarget(c.currentRelationshipID):
 			entryID = nil
 			matchFound = true
 
@@ -339,12 +366,7 @@ func (c *inverseMatchCursor) SeekForward(relationshipID, seekID []byte) (entryID
 	}
 }
 
-// SeekReverse will seek the provided ID in a reverse direction
-func (c *inverseMatchCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
-	if err = c.txn.cc.isDone(); err != nil {
-		return
-	}
-
+func (c *inverseMatchCursor) seekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
 	if err = c.setCursor(relationshipID); err != nil {
 		return
 	}
@@ -359,7 +381,7 @@ func (c *inverseMatchCursor) SeekReverse(relationshipID, seekID []byte) (entryID
 		entryID, _ = c.cur.Seek([]byte(seekID))
 		switch {
 		case entryID == nil:
-		case bytes.Equal(c.targetRelationshipID, c.currentRelationshipID):
+		case c.isTarget(c.currentRelationshipID):
 			entryID = nil
 			matchFound = true
 
@@ -373,6 +395,24 @@ func (c *inverseMatchCursor) SeekReverse(relationshipID, seekID []byte) (entryID
 	}
 }
 
+// SeekForward will seek the provided ID in a forward direction
+func (c *inverseMatchCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.seekForward(relationshipID, seekID)
+}
+
+// SeekReverse will seek the provided ID in a reverse direction
+func (c *inverseMatchCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.seekReverse(relationshipID, seekID)
+}
+
 // First will return the first entry
 func (c *inverseMatchCursor) First() (entryID []byte, err error) {
 	if err = c.txn.cc.isDone(); err != nil {
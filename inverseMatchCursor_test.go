@@ -512,6 +512,18 @@ func Test_inverseMatchCursor_Last(t *testing.T) {
 			relationshipID:  "group_2",
 			expected:        expected{expectedID: "00000000"},
 		},
+		{
+			// Last bucket is the target, the bucket before it is used
+			relationshipKey: "users",
+			relationshipID:  "user_2",
+			expected:        expected{expectedID: "00000001"},
+		},
+		{
+			// Last bucket is the target, the bucket before it is used
+			relationshipKey: "groups",
+			relationshipID:  "group_3",
+			expected:        expected{expectedID: "00000001"},
+		},
 	}
 
 	if err = m.Transaction(context.Background(), func(txn *Transaction) (err error) {
@@ -616,8 +628,8 @@ func Test_inverseMatchCursor_ForEach(t *testing.T) {
 			relationshipKey: "groups",
 			relationshipID:  "group_2",
 			expected: []expected{
-				{expectedID: "00000002"},
 				{expectedID: "00000000"},
+				{expectedID: "00000002"},
 			},
 		},
 	}
@@ -638,6 +650,8 @@ func Test_inverseMatchCursor_ForEach(t *testing.T) {
 		for i, tc := range tcs {
 			f := filters.InverseMatch(tc.relationshipKey, tc.relationshipID)
 			opts := NewIteratingOpts(f)
+			// Inverse match filters are probed while all entries are scanned, even when the filter order is preserved
+			opts.PreserveFilterOrder = true
 			var index int
 			if err = txn.ForEach(func(entryID string, val Value) (err error) {
 				if index > len(tc.expected) {
@@ -667,6 +681,175 @@ func Test_inverseMatchCursor_ForEach(t *testing.T) {
 	}
 }
 
+func Test_inverseMatchCursor_NotIn(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0"),
+		newTestStruct("user_1", "contact_1", "group_1", "1"),
+		newTestStruct("user_2", "contact_0", "group_1", "2"),
+		newTestStruct("user_1", "contact_1", "group_0", "3"),
+		newTestStruct("user_3", "contact_0", "group_1", "4"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters:  []Filter{filters.NotIn("users", "user_0", "user_1")},
+			expected: []string{"00000002", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.NotIn("users", "user_0", "user_1")},
+			reverse:  true,
+			expected: []string{"00000004", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.Match("contacts", "contact_0"), filters.NotIn("users", "user_0", "user_3")},
+			expected: []string{"00000002"},
+		},
+		{
+			filters:  []Filter{filters.NotIn("users", "user_404")},
+			expected: []string{"00000000", "00000001", "00000002", "00000003", "00000004"},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
+func Test_inverseMatchCursor_NotIn_multiValued(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0", "t1", "t2"),
+		newTestStruct("user_1", "contact_0", "group_0", "1", "t2"),
+		newTestStruct("user_2", "contact_0", "group_0", "2"),
+		newTestStruct("user_3", "contact_0", "group_0", "3", "t3", "t4"),
+		newTestStruct("user_4", "contact_0", "group_0", "4", "t4"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters:  []Filter{filters.NotIn("tags", "t1", "t3")},
+			expected: []string{"00000001", "00000002", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.NotIn("tags", "t1", "t3")},
+			reverse:  true,
+			expected: []string{"00000004", "00000002", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Or(filters.NotIn("tags", "t1", "t3"), filters.Match("users", "user_3"))},
+			expected: []string{"00000001", "00000002", "00000003", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.Or(filters.InverseMatch("tags", "t2"), filters.Match("users", "user_0"))},
+			reverse:  true,
+			expected: []string{"00000004", "00000003", "00000002", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.Not(filters.NotIn("tags", "t1", "t3"))},
+			expected: []string{"00000000", "00000003"},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
+func Test_inverseMatchCursor_NotIn_primary(t *testing.T) {
+	opts := defaultOpts
+	opts.DisableQueryPlanner = true
+	for _, o := range []Opts{defaultOpts, opts} {
+		testInverseMatchCursorNotInPrimary(t, o)
+	}
+}
+
+func testInverseMatchCursorNotInPrimary(t *testing.T, opts Opts) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0", "t1", "t2"),
+		newTestStruct("user_1", "contact_0", "group_0", "1", "t2"),
+		newTestStruct("user_2", "contact_0", "group_0", "2"),
+		newTestStruct("user_3", "contact_0", "group_0", "3", "t3", "t4"),
+		newTestStruct("user_4", "contact_0", "group_0", "4", "t4"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, reverse := range []bool{false, true} {
+		expected := []string{"00000001", "00000002", "00000004"}
+		if reverse {
+			expected = []string{"00000004", "00000002", "00000001"}
+		}
+
+		// Inverse filters are probed rather than iterated, even when they are the first filter
+		o := NewFilteringOpts(filters.NotIn("tags", "t1", "t3"), filters.Match("groups", "group_0"))
+		o.PreserveFilterOrder = true
+		o.Reverse = reverse
+		o.Limit = 1
+
+		var (
+			filtered []*testStruct
+			page     Page
+		)
+
+		for {
+			if page, err = m.GetPage(&filtered, o); err != nil {
+				t.Fatal(err)
+			}
+
+			if page.Next == "" {
+				break
+			}
+
+			o.PageToken = page.Next
+		}
+
+		if err = testCheckIDs(filtered, expected); err != nil {
+			t.Fatalf("reverse %v: %v", reverse, err)
+		}
+	}
+}
+
 func testInverseMatchCursorHas(t *testing.T, fn func(c filterCursor, entryID []byte) (value bool, err error)) {
 	type expected struct {
 		value bool
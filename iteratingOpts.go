@@ -9,9 +9,48 @@ func NewIteratingOpts(fs ...Filter) *IteratingOpts {
 	return &i
 }
 
+// newCursorIteratingOpts will return the iterating options used by cursors
+// Note: Cursors are positioned by the relationship IDs of the primary filter, so the filter order is preserved
+func newCursorIteratingOpts(fs []Filter) (o *IteratingOpts) {
+	o = NewIteratingOpts(fs...)
+	o.PreserveFilterOrder = true
+	return
+}
+
 // IteratingOpts represents iterating options
 type IteratingOpts struct {
 	LastID  string
 	Reverse bool
 	Filters []Filter
+
+	// PreserveFilterOrder will use the first filter as the primary filter rather than the filter chosen by
+	// the query planner. The primary filter determines the iteration order of the results and the format of
+	// LastID, so this should be set when the ordering of the results is relied upon
+	// Note: Inverse filters (InverseMatch and NotIn) are always probed, so the first filter which is not an
+	// inverse filter is used. When the planner chooses the primary, LastID records it so the following
+	// pages are iterated with the same primary
+	PreserveFilterOrder bool
+
+	// OrderBy will iterate in the order of a relationship's IDs rather than the order chosen by the
+	// primary filter. When set, all filters are applied as secondary filters and LastID will encode
+	// the sort position (relationship ID and entry ID)
+	OrderBy *OrderBy
+
+	// Distinct will ensure entries are only yielded once when the primary filter matches multiple relationship
+	// IDs of an entry (e.g. a range over a many-to-many relationship). Entries are yielded at their first
+	// matching relationship ID in the direction of iteration, the previous pages of GetPage hold the same
+	// entries as the corresponding next pages
+	// Note: This is enabled by default for FilteringOpts created by NewFilteringOpts. Yielded entries are tracked
+	// in memory for the duration of the iteration, and iterating from a LastID reads every entry of the matching
+	// relationship IDs which precede it once per iteration
+	Distinct bool
+}
+
+// applyOrder will return whether or not iteration should be in reverse for the provided direction
+func (o *IteratingOpts) applyOrder(reverse bool) (isReverse bool) {
+	if o.OrderBy != nil && o.OrderBy.Descending {
+		return !reverse
+	}
+
+	return reverse
 }
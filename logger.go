@@ -0,0 +1,6 @@
+package mojura
+
+// Logger is used to log messages
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
@@ -1,9 +1,9 @@
 package mojura
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"path"
 	"reflect"
@@ -23,6 +23,28 @@ const (
 	ErrNotInitialized = errors.Error("service has not been properly initialized")
 	// ErrRelationshipNotFound is returned when an relationship is not available for the given relationship key
 	ErrRelationshipNotFound = errors.Error("relationship was not found")
+	// ErrTimestampsNotIndexed is returned when a timestamp filter is used without timestamp indexes enabled
+	ErrTimestampsNotIndexed = errors.Error("timestamps are not indexed, see Opts.IndexTimestamps")
+	// ErrInvalidNormalization is returned when a normalization is provided for an unknown relationship key
+	ErrInvalidNormalization = errors.Error("invalid normalization, relationship key does not exist")
+	// ErrTextNotIndexed is returned when a text filter is used without the text index enabled
+	ErrTextNotIndexed = errors.Error("text is not indexed, see Opts.IndexText")
+	// ErrMissingTextFilter is returned when ranking by text without any text filters
+	ErrMissingTextFilter = errors.Error("cannot rank by text, no text filters were provided")
+	// ErrInvalidRankedLastID is returned when the last ID of a ranked query is malformed
+	ErrInvalidRankedLastID = errors.Error("invalid last ID, ranked queries expect the last ID of a ranked query")
+	// ErrInvalidLastID is returned when the primary recorded by a last ID does not match the filters of the query
+	ErrInvalidLastID = errors.Error("invalid last ID, last ID was created for a different query")
+	// ErrRankedPageToken is returned when requesting a page of a ranked query
+	ErrRankedPageToken = errors.Error("ranked queries do not support page tokens, use LastID instead")
+	// ErrInvalidPageToken is returned when a page token is malformed or has been tampered with
+	ErrInvalidPageToken = errors.Error("invalid page token")
+	// ErrPageTokenMismatch is returned when a page token is used with a different query than it was created for
+	ErrPageTokenMismatch = errors.Error("invalid page token, token was created for a different query")
+	// ErrMissingPageQueryID is returned when requesting a page of filters which cannot be serialized without a page query ID
+	ErrMissingPageQueryID = errors.Error("invalid page query, filters cannot be serialized and no page query ID was provided")
+	// ErrReservedRelationshipKey is returned when a relationship key is reserved for a system index
+	ErrReservedRelationshipKey = errors.Error("invalid relationship key, key is reserved for a system index")
 	// ErrLookupNotFound is returned when a lookup is not available for the given lookup key
 	ErrLookupNotFound = errors.Error("lookup was not found")
 	// ErrEntryNotFound is returned when an entry is not available for the given ID
@@ -41,7 +63,7 @@ const (
 	ErrEmptyFilters = errors.Error("invalid relationship pairs, cannot be empty")
 	// ErrInversePrimaryFilter is returned when the primary filter is set as an inverse comparison
 	ErrInversePrimaryFilter = errors.Error("invalid primary filter, cannot be an inverse comparison")
-	// ErrContextCancelled is returned when a transaction ends early from context
+	// ErrContextCancelled is returned when a transaction ends early from context or is used after it has completed
 	ErrContextCancelled = errors.Error("context cancelled")
 	// ErrEmptyEntryID is returned when an entry ID is empty
 	ErrEmptyEntryID = errors.Error("invalid entry ID, cannot be empty")
@@ -54,6 +76,8 @@ var (
 	entriesBktKey       = []byte("entries")
 	relationshipsBktKey = []byte("relationships")
 	lookupsBktKey       = []byte("lookups")
+	missingBktKey       = []byte("missing")
+	collectionsBktKey   = []byte("collections")
 )
 
 // New will return a new instance of Mojura
@@ -63,44 +87,78 @@ func New(name, dir string, example Value, relationships ...string) (cc *Mojura,
 
 // NewWithOpts will return a new instance of Mojura
 func NewWithOpts(name, dir string, example Value, opts Opts, relationships ...string) (mp *Mojura, err error) {
-	var m Mojura
-	if err = opts.Validate(); err != nil {
+	var m *Mojura
+	if m, err = newMojura(example, opts, relationships); err != nil {
 		return
 	}
 
-	if len(example.GetRelationships()) != len(relationships) {
-		err = ErrInvalidNumberOfRelationships
+	m.logsDir = path.Join(dir, "logs")
+
+	if err = os.MkdirAll(m.logsDir, 0744); err != nil {
 		return
 	}
 
-	m.opts = &opts
-	m.entryType = getMojuraType(example)
-	m.logsDir = path.Join(dir, "logs")
-	m.indexFmt = fmt.Sprintf("%s0%dd", "%", opts.IndexLength)
+	if err = m.init(name, dir); err != nil {
+		return
+	}
 
-	if err = os.MkdirAll(m.logsDir, 0744); err != nil {
+	if err = m.initBuckets(relationships); err != nil {
 		return
 	}
 
-	if err = m.init(name, dir, relationships); err != nil {
+	if m.a, err = newActions(m.logsDir, name, m.handleLogRotation); err != nil {
 		return
 	}
 
-	if m.a, err = actions.New(m.logsDir, name); err != nil {
+	// Set return pointer
+	mp = m
+	return
+}
+
+func newMojura(example Value, opts Opts, relationships []string) (mp *Mojura, err error) {
+	var m Mojura
+	if err = opts.Validate(); err != nil {
 		return
 	}
 
-	m.a.SetRotateFn(m.handleLogRotation)
-	m.a.SetRotateInterval(time.Minute)
-	// Set maximum number of lines to 10,000
-	m.a.SetNumLines(100000)
+	if len(example.GetRelationships()) != len(relationships) {
+		err = ErrInvalidNumberOfRelationships
+		return
+	}
+
+	for _, relationship := range relationships {
+		if isTimestampRelationship([]byte(relationship)) || isTextRelationship([]byte(relationship)) {
+			err = ErrReservedRelationshipKey
+			return
+		}
+	}
+
+	if m.normalizations, err = getNormalizations(opts.Normalizations, relationships); err != nil {
+		return
+	}
+
+	m.opts = &opts
+	m.entryType = getMojuraType(example)
+	m.indexFmt = fmt.Sprintf("%s0%dd", "%", opts.IndexLength)
+	m.entriesLogKey = entriesBktKey
 	// Initialize new batcher
 	m.b = newBatcher(&m)
-	// Set return pointer
 	mp = &m
 	return
 }
 
+func newActions(logsDir, name string, onRotate func(filename string)) (a *actions.Actions, err error) {
+	if a, err = actions.New(logsDir, name); err != nil {
+		return
+	}
+
+	a.SetRotateFn(onRotate)
+	a.SetRotateInterval(time.Minute)
+	// Set maximum number of lines to 10,000
+	a.SetNumLines(100000)
+	return
+}
+
 // Mojura is the DB manager
 type Mojura struct {
 	db  backend.Backend
@@ -112,16 +170,32 @@ type Mojura struct {
 	logsDir  string
 	indexFmt string
 
+	// Key used to sign page tokens
+	pageTokenKey []byte
+
+	// Parent DB, set when Mojura is a collection within a DB
+	parent *DB
+	// Bucket namespace, set when Mojura is a collection within a DB
+	namespace     []byte
+	entriesLogKey []byte
+
 	// Element type
 	entryType reflect.Type
 
 	relationships [][]byte
+	// Normalizations of each relationship, in the order of the relationships
+	normalizations []Normalization
+	references     []reference
+	dependents     []dependent
+
+	// In-flight transactions, drained before the underlying stores are closed
+	txns transactionTracker
 
 	// Closed state
 	closed atoms.Bool
 }
 
-func (m *Mojura) init(name, dir string, relationships []string) (err error) {
+func (m *Mojura) init(name, dir string) (err error) {
 	indexFilename := path.Join(dir, name+".idb")
 	filename := path.Join(dir, name+".bdb")
 
@@ -133,35 +207,177 @@ func (m *Mojura) init(name, dir string, relationships []string) (err error) {
 		return fmt.Errorf("error opening db for %s (%s): %v", name, dir, err)
 	}
 
+	return
+}
+
+func (m *Mojura) initBuckets(relationships []string) (err error) {
 	err = m.db.Transaction(func(txn backend.Transaction) (err error) {
-		if _, err = txn.GetOrCreateBucket(entriesBktKey); err != nil {
+		var root backend.Transaction
+		if root, err = getOrCreateRoot(txn, m.namespace); err != nil {
+			return
+		}
+
+		if _, err = root.GetOrCreateBucket(entriesBktKey); err != nil {
 			return
 		}
 
-		if _, err = txn.GetOrCreateBucket(lookupsBktKey); err != nil {
+		if _, err = root.GetOrCreateBucket(lookupsBktKey); err != nil {
 			return
 		}
 
 		var relationshipsBkt backend.Bucket
-		if relationshipsBkt, err = txn.GetOrCreateBucket(relationshipsBktKey); err != nil {
+		if relationshipsBkt, err = root.GetOrCreateBucket(relationshipsBktKey); err != nil {
+			return
+		}
+
+		var missingBkt backend.Bucket
+		if missingBkt, err = root.GetOrCreateBucket(missingBktKey); err != nil {
+			return
+		}
+
+		var countsBkt backend.Bucket
+		if countsBkt, err = root.GetOrCreateBucket(countsBktKey); err != nil {
+			return
+		}
+
+		var statsBkt backend.Bucket
+		if statsBkt, err = root.GetOrCreateBucket(statsBktKey); err != nil {
 			return
 		}
 
-		for _, relationship := range relationships {
+		if m.pageTokenKey = m.opts.PageTokenKey; len(m.pageTokenKey) == 0 {
+			if m.pageTokenKey, err = getOrCreatePageTokenKey(statsBkt); err != nil {
+				return
+			}
+		}
+
+		if getCount(statsBkt, entriesStatKey) == 0 {
+			// Count entries which existed before the stats were created
+			if err = putCount(statsBkt, entriesStatKey, countKeys(root.GetBucket(entriesBktKey))); err != nil {
+				return
+			}
+		}
+
+		for i, relationship := range relationships {
 			rbs := []byte(relationship)
-			if _, err = relationshipsBkt.GetOrCreateBucket(rbs); err != nil {
+			if err = m.initNormalization(root, relationshipsBkt, missingBkt, countsBkt, statsBkt, rbs, i); err != nil {
+				return
+			}
+
+			var relationshipBkt backend.Bucket
+			if relationshipBkt, err = relationshipsBkt.GetOrCreateBucket(rbs); err != nil {
+				return
+			}
+
+			if err = m.initMissingBucket(root, missingBkt, statsBkt, rbs, i); err != nil {
+				return
+			}
+
+			if err = initCountsBucket(countsBkt, relationshipBkt, rbs); err != nil {
 				return
 			}
 
 			m.relationships = append(m.relationships, rbs)
 		}
 
+		if err = m.initTimestampBuckets(root, relationshipsBkt, countsBkt); err != nil {
+			return
+		}
+
+		return m.initTextBucket(root, relationshipsBkt, countsBkt)
+	})
+
+	return
+}
+
+func initCountsBucket(countsBkt, relationshipBkt backend.Bucket, relationship []byte) (err error) {
+	if countsBkt.GetBucket(relationship) != nil {
+		// Counts already exist for this relationship
+		return
+	}
+
+	var bkt backend.Bucket
+	if bkt, err = countsBkt.GetOrCreateBucket(relationship); err != nil {
 		return
+	}
+
+	// Count relationships which existed before the counts were created
+	return relationshipBkt.ForEach(func(relationshipID, _ []byte) (err error) {
+		var relationshipIDBkt backend.Bucket
+		if relationshipIDBkt = relationshipBkt.GetBucket(relationshipID); relationshipIDBkt == nil {
+			return
+		}
+
+		return putCount(bkt, relationshipID, countKeys(relationshipIDBkt))
 	})
+}
+
+func (m *Mojura) initMissingBucket(root backend.Transaction, missingBkt, statsBkt backend.Bucket, relationship []byte, index int) (err error) {
+	statKey := getMissingStatKey(relationship)
+	if bkt := missingBkt.GetBucket(relationship); bkt != nil {
+		// Missing index already exists for this relationship, ensure it has been counted
+		if getCount(statsBkt, statKey) == 0 {
+			err = putCount(statsBkt, statKey, countKeys(bkt))
+		}
+
+		return
+	}
+
+	var bkt backend.Bucket
+	if bkt, err = missingBkt.GetOrCreateBucket(relationship); err != nil {
+		return
+	}
+
+	// Index entries which existed before the missing index was created
+	if err = root.GetBucket(entriesBktKey).ForEach(func(entryID, bs []byte) (err error) {
+		var val Value
+		if val, err = m.newValueFromBytes(bs); err != nil {
+			return
+		}
+
+		if !isMissingRelationship(m.normalizeRelationships(val.GetRelationships()), index) {
+			return
+		}
 
+		return bkt.Put(entryID, nil)
+	}); err != nil {
+		return
+	}
+
+	return putCount(statsBkt, statKey, countKeys(bkt))
+}
+
+func (m *Mojura) getRelationshipIndex(relationshipKey []byte) (index int, err error) {
+	for i, relationship := range m.relationships {
+		if bytes.Equal(relationship, relationshipKey) {
+			return i, nil
+		}
+	}
+
+	err = ErrRelationshipNotFound
 	return
 }
 
+// getReferenceTarget will return the referenced collection for a relationship index
+// Note: If no reference has been set, the current instance is returned
+func (m *Mojura) getReferenceTarget(index int) (target *Mojura) {
+	for _, r := range m.references {
+		if r.index == index {
+			return r.target
+		}
+	}
+
+	return m
+}
+
+func (m *Mojura) sharesBackend(target *Mojura) (ok bool) {
+	if m == target {
+		return true
+	}
+
+	return m.parent != nil && m.parent == target.parent
+}
+
 func (m *Mojura) newReflectValue() (value reflect.Value) {
 	// Zero value of the entry type
 	return reflect.New(m.entryType)
@@ -195,24 +411,7 @@ func (m *Mojura) newValueFromBytes(bs []byte) (val Value, err error) {
 }
 
 func (m *Mojura) handleLogRotation(filename string) {
-	var err error
-	archiveDir := path.Join(m.logsDir, "archived")
-	name := path.Base(filename)
-	destination := path.Join(archiveDir, name)
-
-	if err = os.MkdirAll(archiveDir, 0744); err != nil {
-		// TODO: Add error logging here after we implement output interface to mojura
-		log.Printf("error creating archive directory: %v\n", err)
-		return
-	}
-
-	if err = os.Rename(filename, destination); err != nil {
-		// TODO: Add error logging here after we implement output interface to mojura
-		log.Printf("error renaming file: %v\n", err)
-		return
-	}
-
-	return
+	archiveLog(m.logsDir, filename)
 }
 
 func (m *Mojura) transaction(fn func(backend.Transaction, *actions.Transaction) error) (err error) {
@@ -228,30 +427,22 @@ func (m *Mojura) transaction(fn func(backend.Transaction, *actions.Transaction)
 }
 
 func (m *Mojura) runTransaction(ctx context.Context, txn backend.Transaction, atxn *actions.Transaction, fn TransactionFn) (err error) {
-	t := newTransaction(ctx, m, txn, atxn)
+	t := newTransaction(newContextContainer(ctx), m, txn, atxn)
+	// Ensure the transaction is marked as unusable once the function has returned
 	defer t.teardown()
 	// Always ensure index has been flushed
 	defer m.idx.Flush()
-	errCh := make(chan error)
 
-	// Call function from within goroutine
-	go func() {
-		// Pass returning error to error channel
-		errCh <- fn(&t)
-	}()
+	// Call function within the current goroutine. This ensures the backend transaction
+	// will not be completed until the function has returned
+	fnErr := fn(&t)
 
-	select {
-	case err = <-errCh:
-	case <-t.cc.Done():
-		// Context is done, attempt to set error from Context
-		if err = t.cc.Err(); err != nil {
-			return
-		}
-
-		err = ErrContextCancelled
+	// Context errors take precedence, attempt to set error from Context
+	if err = t.cc.Err(); err != nil {
+		return
 	}
 
-	return
+	return fnErr
 }
 
 // New will insert a new entry with the given value and the associated relationships
@@ -298,6 +489,37 @@ func (m *Mojura) GetFiltered(entries interface{}, o *FilteringOpts) (lastID stri
 	return
 }
 
+// GetPage will attempt to get a page of filtered entries along with the tokens of the next and previous pages
+func (m *Mojura) GetPage(entries interface{}, o *FilteringOpts) (page Page, err error) {
+	err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		page, err = txn.GetPage(entries, o)
+		return
+	})
+
+	return
+}
+
+// GetFilteredWithIncluded will attempt to get the filtered entries along with the related entries
+// for the relationship keys set within FilteringOpts.Include
+func (m *Mojura) GetFilteredWithIncluded(entries interface{}, o *FilteringOpts) (lastID string, included Included, err error) {
+	err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		lastID, included, err = txn.GetFilteredWithIncluded(entries, o)
+		return
+	})
+
+	return
+}
+
+// Explain will execute the filtered query and describe how it was executed
+func (m *Mojura) Explain(o *FilteringOpts) (e *Explanation, err error) {
+	err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		e, err = txn.Explain(o)
+		return
+	})
+
+	return
+}
+
 // GetFirst will attempt to get the first entry which matches the provided filters
 // Note: Will return ErrEntryNotFound if no match is found
 func (m *Mojura) GetFirst(val Value, o *IteratingOpts) (err error) {
@@ -341,10 +563,11 @@ func (m *Mojura) ForEachID(fn ForEachIDFn, o *IteratingOpts) (err error) {
 }
 
 // Cursor will return an iterating cursor
+// Note: The first filter which is not an inverse filter is used as the primary filter, the query planner is not used
 func (m *Mojura) Cursor(fn func(Cursor) error, fs ...Filter) (err error) {
 	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
 		var c Cursor
-		if c, err = txn.cursor(fs); err != nil {
+		if c, err = txn.Cursor(fs...); err != nil {
 			return
 		}
 
@@ -385,8 +608,7 @@ func (m *Mojura) Remove(entryID string) (err error) {
 	return
 }
 
-// Transaction will initialize a transaction
-func (m *Mojura) Transaction(ctx context.Context, fn func(*Transaction) error) (err error) {
+func (m *Mojura) writeTransaction(ctx context.Context, fn TransactionFn) (err error) {
 	err = m.transaction(func(txn backend.Transaction, atxn *actions.Transaction) (err error) {
 		return m.runTransaction(ctx, txn, atxn, fn)
 	})
@@ -394,8 +616,35 @@ func (m *Mojura) Transaction(ctx context.Context, fn func(*Transaction) error) (
 	return
 }
 
+func (m *Mojura) closeStores() (err error) {
+	var errs errors.ErrorList
+	if m.parent == nil {
+		// Backend and actions are owned by this instance, close them
+		errs.Push(m.db.Close())
+		errs.Push(m.a.Close())
+	}
+
+	errs.Push(m.idx.Close())
+	return errs.Err()
+}
+
+// Transaction will initialize a transaction
+func (m *Mojura) Transaction(ctx context.Context, fn func(*Transaction) error) (err error) {
+	if !m.txns.begin() {
+		return errors.ErrIsClosed
+	}
+	defer m.txns.end()
+
+	return m.writeTransaction(ctx, fn)
+}
+
 // ReadTransaction will initialize a read-only transaction
 func (m *Mojura) ReadTransaction(ctx context.Context, fn func(*Transaction) error) (err error) {
+	if !m.txns.begin() {
+		return errors.ErrIsClosed
+	}
+	defer m.txns.end()
+
 	err = m.db.ReadTransaction(func(txn backend.Transaction) (err error) {
 		return m.runTransaction(ctx, txn, nil, fn)
 	})
@@ -404,18 +653,111 @@ func (m *Mojura) ReadTransaction(ctx context.Context, fn func(*Transaction) erro
 }
 
 // Batch will initialize a batch
+// Note: The provided function will be given a transaction which uses the provided context. If the
+// context ends before the batch is flushed, the function will not be called and the context error
+// will be returned. If the context ends while the batch is running, the context error is returned
+// without waiting for the batch to complete.
 func (m *Mojura) Batch(ctx context.Context, fn func(*Transaction) error) (err error) {
-	return <-m.b.Append(ctx, fn)
+	r := m.BatchWithResult(ctx, fn)
+	return r.Err
+}
+
+// BatchWithResult will initialize a batch and return the result of the call, which includes
+// the number of times the call was ran
+func (m *Mojura) BatchWithResult(ctx context.Context, fn func(*Transaction) error) (r BatchResult) {
+	if m.closed.Get() {
+		r.Err = errors.ErrIsClosed
+		return
+	}
+
+	resultC := m.b.Append(ctx, fn)
+
+	select {
+	case r = <-resultC:
+	case <-ctx.Done():
+		r.Err = ctx.Err()
+	}
+
+	return
+}
+
+// SetReference will declare a relationship key as referencing the entry IDs of the target. Relationship IDs
+// for the key will be validated to exist within the target, and the provided policy will be applied to
+// dependent entries when a referenced entry is removed
+// Note: The target must be the same instance of Mojura or a collection within the same DB. References
+// are expected to be set during initialization, before the collections are used
+func (m *Mojura) SetReference(relationshipKey string, target *Mojura, onRemove ReferencePolicy) (err error) {
+	if err = onRemove.Validate(); err != nil {
+		return
+	}
+
+	if !m.sharesBackend(target) {
+		return ErrInvalidReferenceTarget
+	}
+
+	var r reference
+	if r.index, err = m.getRelationshipIndex([]byte(relationshipKey)); err != nil {
+		return
+	}
+
+	for _, existing := range m.references {
+		if existing.index == r.index {
+			return ErrReferenceExists
+		}
+	}
+
+	if _, ok := m.newEntryValue().(ReferenceUnsetter); !ok && onRemove == ReferenceUnset {
+		return ErrReferenceUnsetNotSupported
+	}
+
+	r.target = target
+
+	var d dependent
+	d.source = m
+	d.relationshipKey = m.relationships[r.index]
+	d.policy = onRemove
+
+	m.references = append(m.references, r)
+	target.dependents = append(target.dependents, d)
+	return
 }
 
 // Close will close the selected instance of Mojura
+// Note: Pending batch calls will be flushed and in-flight transactions will complete before
+// the underlying stores are closed
 func (m *Mojura) Close() (err error) {
+	return m.CloseCtx(context.Background())
+}
+
+// CloseCtx will close the selected instance of Mojura. New calls will be rejected with
+// ErrIsClosed while any pending batch calls are flushed and any in-flight transactions
+// complete. If the context ends before the pending calls have drained, the context error
+// is returned and the underlying stores will be closed once the drain completes
+func (m *Mojura) CloseCtx(ctx context.Context) (err error) {
 	if !m.closed.Set(true) {
 		return errors.ErrIsClosed
 	}
 
-	var errs errors.ErrorList
-	errs.Push(m.db.Close())
-	errs.Push(m.a.Close())
-	return errs.Err()
+	// Stop accepting new transactions
+	m.txns.close()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-m.b.Close()
+		m.txns.wait()
+	}()
+
+	select {
+	case <-drained:
+		return m.closeStores()
+	case <-ctx.Done():
+		// Context ended before the drain completed, close the stores in the background
+		go func() {
+			<-drained
+			m.closeStores()
+		}()
+
+		return ctx.Err()
+	}
 }
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gdbu/stringset"
 	"github.com/hatchify/errors"
+	"github.com/mojura/backend"
 	"github.com/mojura/mojura/filters"
 )
 
@@ -139,6 +141,62 @@ func TestMojura_Get_context(t *testing.T) {
 	}
 }
 
+func TestMojura_Transaction_cancelled(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	var leaked *Transaction
+	ctx, cancel := context.WithCancel(context.Background())
+	if err = c.Transaction(ctx, func(txn *Transaction) (err error) {
+		leaked = txn
+		if _, err = txn.New(&foobar); err != nil {
+			return
+		}
+
+		cancel()
+
+		if _, err = txn.New(&foobar); err != ErrContextCancelled {
+			return fmt.Errorf("invalid error, expected %v and received %v", ErrContextCancelled, err)
+		}
+
+		return nil
+	}); err != context.Canceled {
+		t.Fatalf("invalid error, expected %v and received %v", context.Canceled, err)
+	}
+
+	if _, err = leaked.New(&foobar); err != ErrContextCancelled {
+		t.Fatalf("invalid error, expected %v and received %v", ErrContextCancelled, err)
+	}
+
+	var exists bool
+	if exists, err = c.Exists("00000000"); err != nil {
+		t.Fatal(err)
+	} else if exists {
+		t.Fatal("invalid exists value, expected cancelled transaction to be rolled back")
+	}
+
+	if err = c.Transaction(context.Background(), func(txn *Transaction) (err error) {
+		leaked = txn
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var fb testStruct
+	if err = leaked.Get("00000000", &fb); err != ErrContextCancelled {
+		t.Fatalf("invalid error, expected %v and received %v", ErrContextCancelled, err)
+	}
+}
+
 func TestMojura_GetFiltered_many_to_many(t *testing.T) {
 	var (
 		c   *Mojura
@@ -350,6 +408,172 @@ func TestMojura_GetFiltered_seek(t *testing.T) {
 	}
 }
 
+func TestMojura_GetFiltered_seek_separator(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	entries := []*testStruct{
+		newTestStruct("user_1", "contact_1", "group_1", "FOO FOO", "a::b"),
+		newTestStruct("user_1", "contact_1", "group_1", "FOO FOO", "a::"),
+		newTestStruct("user_1", "contact_1", "group_1", "FOO FOO", `a\b`),
+		newTestStruct("user_1", "contact_1", "group_1", "FOO FOO", "a:"),
+	}
+
+	for _, entry := range entries {
+		if entry.ID, err = c.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o := NewFilteringOpts(filters.Prefix("tags", "a"))
+	o.Limit = 1
+
+	// Relationship IDs which contain the seek ID separator are iterated in relationship ID order
+	expected := []string{"00000003", "00000001", "00000000", "00000002"}
+	var ids []string
+	for i := 0; i <= len(expected); i++ {
+		var filtered []*testStruct
+		if o.LastID, err = c.GetFiltered(&filtered, o); err != nil && err != ErrEntryNotFound {
+			t.Fatal(err)
+		}
+
+		for _, entry := range filtered {
+			ids = append(ids, entry.ID)
+		}
+
+		if o.LastID == "" {
+			break
+		}
+	}
+
+	if fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Fatalf("invalid IDs, expected <%v> and received <%v>", expected, ids)
+	}
+}
+
+func TestMojura_GetFiltered_query(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	entries := []*testStruct{
+		newTestStruct("user_1", "contact_1", "group_1", "0"),
+		newTestStruct("user_2", "contact_1", "group_2", "1"),
+		newTestStruct("user_3", "contact_2", "group_1", "2"),
+		newTestStruct("user_1", "contact_3", "group_3", "3"),
+	}
+
+	for _, entry := range entries {
+		if _, err = c.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var fs []Filter
+	if fs, err = filters.Parse(`groups IN ("group_1", "group_3") AND contacts >= "contact_2" AND NOT users = "user_3"`); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, c, []testFilteredCase{
+		{
+			filters:  fs,
+			expected: []string{"00000003"},
+		},
+	})
+}
+
+func TestMojura_GetFiltered_query_negated_comparison(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	entries := []*testStruct{
+		newTestStruct("user_1", "contact_1", "group_1", "0", "1", "5"),
+		newTestStruct("user_1", "contact_1", "group_1", "1", "5"),
+		newTestStruct("user_1", "contact_1", "group_1", "2", "1"),
+		newTestStruct("user_1", "contact_1", "group_1", "3"),
+	}
+
+	for _, entry := range entries {
+		if _, err = c.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var fs []Filter
+	if fs, err = filters.Parse(`NOT tags < "3"`); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, c, []testFilteredCase{
+		{
+			// Entries with a tag less than "3" are excluded, entries without tags are included
+			filters:  fs,
+			expected: []string{"00000001", "00000003"},
+		},
+	})
+}
+
+func TestMojura_GetFiltered_typed(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	for _, price := range []int64{-5, 9, 10, 100} {
+		var r Relationships
+		AppendTyped(&r, price)
+		entry := newTestStruct("user_1", "contact_1", "group_1", "", r[0]...)
+		if _, err = c.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testFilteredCases(t, c, []testFilteredCase{
+		{
+			filters:  []Filter{filters.GreaterThanTyped("tags", int64(9))},
+			expected: []string{"00000002", "00000003"},
+		},
+		{
+			filters:  []Filter{filters.LessThanTyped("tags", 10)},
+			expected: []string{"00000000", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.RangeTyped("tags", 0, 100)},
+			reverse:  true,
+			expected: []string{"00000003", "00000002", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.MatchTyped("tags", int64(-5))},
+			expected: []string{"00000000"},
+		},
+	})
+}
+
 func TestMojura_Edit(t *testing.T) {
 	var (
 		c   *Mojura
@@ -1145,6 +1369,433 @@ func TestMojura_Batch(t *testing.T) {
 	return
 }
 
+func TestMojura_Batch_context(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var called bool
+	if err = c.Batch(ctx, func(txn *Transaction) (err error) {
+		called = true
+		_, err = txn.New(&foobar)
+		return
+	}); err != context.Canceled {
+		t.Fatalf("invalid error, expected %v and received %v", context.Canceled, err)
+	}
+
+	if err = c.Batch(context.Background(), func(txn *Transaction) (err error) {
+		_, err = txn.New(&foobar)
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if called {
+		t.Fatal("invalid call state, expected cancelled call to be dropped")
+	}
+
+	var count int
+	if err = c.ForEachID(func(entryID string) (err error) {
+		count++
+		return
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 1, count)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	// Hold the batch open until the caller has returned
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	err = c.Batch(ctx, func(txn *Transaction) (err error) {
+		close(started)
+		<-release
+		_, err = txn.New(&foobar)
+		return
+	})
+	close(release)
+
+	if err != context.Canceled {
+		t.Fatalf("invalid error, expected %v and received %v", context.Canceled, err)
+	}
+}
+
+func TestMojura_Batch_retry_policy(t *testing.T) {
+	type testcase struct {
+		strategy    BatchRetryStrategy
+		maxAttempts int
+
+		expectedAttempts []int
+		// Index of the call which is notified of the group error
+		groupErrIndex int
+	}
+
+	tcs := []testcase{
+		{
+			strategy:         BatchRetryPrefix,
+			maxAttempts:      DefaultBatchRetryAttempts,
+			expectedAttempts: []int{2, 2, 2, 1, 1, 1, 1, 1},
+			groupErrIndex:    -1,
+		},
+		{
+			strategy:         BatchRetryBisect,
+			maxAttempts:      DefaultBatchRetryAttempts,
+			expectedAttempts: []int{3, 3, 4, 4, 1, 1, 1, 1},
+			groupErrIndex:    -1,
+		},
+		{
+			strategy:         BatchRetryIsolate,
+			maxAttempts:      DefaultBatchRetryAttempts,
+			expectedAttempts: []int{2, 2, 2, 1, 1, 1, 1, 1},
+			groupErrIndex:    -1,
+		},
+		{
+			// Failing call is exhausted before bisection isolates it
+			strategy:         BatchRetryBisect,
+			maxAttempts:      2,
+			expectedAttempts: []int{3, 3, 3, 3, 1, 1, 1, 1},
+			groupErrIndex:    2,
+		},
+	}
+
+	errFailing := errors.Error("failing call")
+	for i, tc := range tcs {
+		if err := testBatchRetryPolicy(tc.strategy, tc.maxAttempts, errFailing, tc.expectedAttempts, tc.groupErrIndex); err != nil {
+			t.Fatalf("%v (test case #%d)", err, i)
+		}
+	}
+}
+
+func testBatchRetryPolicy(strategy BatchRetryStrategy, maxAttempts int, errFailing error, expectedAttempts []int, groupErrIndex int) (err error) {
+	var c *Mojura
+	opts := defaultOpts
+	opts.MaxBatchCalls = len(expectedAttempts)
+	opts.MaxBatchDuration = time.Second * 10
+	opts.BatchRetryPolicy.Strategy = strategy
+	opts.BatchRetryPolicy.MaxAttempts = maxAttempts
+
+	if c, err = testInitWithOpts(opts); err != nil {
+		testTeardown(c)
+		return
+	}
+	defer testTeardown(c)
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	// Calls are appended directly to the batcher to ensure the order of the batch
+	resultCs := make([]chan BatchResult, 0, len(expectedAttempts))
+	for i := range expectedAttempts {
+		i := i
+		resultCs = append(resultCs, c.b.Append(context.Background(), func(txn *Transaction) (err error) {
+			if _, err = txn.New(&foobar); err != nil {
+				return
+			}
+
+			if i == 3 {
+				return errFailing
+			}
+
+			return
+		}))
+	}
+
+	var succeeded int
+	for i, resultC := range resultCs {
+		r := <-resultC
+		switch {
+		case i == 3 && r.Err != errFailing:
+			return fmt.Errorf("invalid error for call #%d, expected %v and received %v", i, errFailing, r.Err)
+		case i == groupErrIndex && (r.Err == nil || r.Err == errFailing):
+			return fmt.Errorf("invalid error for call #%d, expected group error and received %v", i, r.Err)
+		case i != 3 && i != groupErrIndex && r.Err != nil:
+			return fmt.Errorf("invalid error for call #%d, expected nil and received %v", i, r.Err)
+		case r.Attempts != expectedAttempts[i]:
+			return fmt.Errorf("invalid number of attempts for call #%d, expected %d and received %d", i, expectedAttempts[i], r.Attempts)
+		case r.Retried() != (expectedAttempts[i] > 1):
+			return fmt.Errorf("invalid retried value for call #%d, expected %v and received %v", i, expectedAttempts[i] > 1, r.Retried())
+		case r.Attempts > maxAttempts+1:
+			return fmt.Errorf("invalid number of attempts for call #%d, expected no more than %d and received %d", i, maxAttempts+1, r.Attempts)
+		case r.Err == nil:
+			succeeded++
+		}
+	}
+
+	var count int
+	if err = c.ForEachID(func(entryID string) (err error) {
+		count++
+		return
+	}, nil); err != nil {
+		return
+	}
+
+	if count != succeeded {
+		return fmt.Errorf("invalid number of entries, expected %d and received %d", succeeded, count)
+	}
+
+	return
+}
+
+func TestMojura_Batch_retry_backoff(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.MaxBatchCalls = 1
+	opts.BatchRetryPolicy.Backoff = time.Millisecond * 100
+	opts.BatchRetryPolicy.MaxBackoff = time.Millisecond * 100
+
+	if c, err = testInitWithOpts(opts); err != nil {
+		testTeardown(c)
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	// Fail the first commit with a transient error
+	c.db = &testFailingBackend{Backend: c.db, failures: 1}
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	start := time.Now()
+	resultC := c.b.Append(context.Background(), func(txn *Transaction) (err error) {
+		_, err = txn.New(&foobar)
+		return
+	})
+
+	// The batch is flushed within Append, the backoff must not be waited on within the batcher lock
+	select {
+	case r := <-resultC:
+		t.Fatalf("invalid result, expected call to be waiting for backoff and received %+v", r)
+	default:
+	}
+
+	r := <-resultC
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+
+	if r.Attempts != 2 {
+		t.Fatalf("invalid number of attempts, expected %d and received %d", 2, r.Attempts)
+	}
+
+	if elapsed := time.Since(start); elapsed < opts.BatchRetryPolicy.Backoff {
+		t.Fatalf("invalid elapsed time, expected at least %v and received %v", opts.BatchRetryPolicy.Backoff, elapsed)
+	}
+}
+
+func TestMojura_Batch_retry_backoff_exhausted(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.BatchRetryPolicy.MaxAttempts = 2
+	opts.BatchRetryPolicy.Backoff = time.Millisecond
+	opts.BatchRetryPolicy.MaxBackoff = time.Millisecond
+
+	if c, err = testInitWithOpts(opts); err != nil {
+		testTeardown(c)
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	// Fail every commit with a transient error
+	c.db = &testFailingBackend{Backend: c.db, failures: -1}
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	r := c.BatchWithResult(context.Background(), func(txn *Transaction) (err error) {
+		_, err = txn.New(&foobar)
+		return
+	})
+
+	if r.Err != errTestTransient {
+		t.Fatalf("invalid error, expected %v and received %v", errTestTransient, r.Err)
+	}
+
+	if r.Attempts != 3 {
+		t.Fatalf("invalid number of attempts, expected %d and received %d", 3, r.Attempts)
+	}
+}
+
+func TestMojura_Close_drain(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.MaxBatchDuration = time.Second * 10
+
+	if c, err = testInitWithOpts(opts); err != nil {
+		testTeardown(c)
+		t.Fatal(err)
+	}
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.Batch(context.Background(), func(txn *Transaction) (err error) {
+				_, err = txn.New(&foobar)
+				return
+			})
+		}()
+	}
+
+	// Give the batch calls time to be appended
+	time.Sleep(time.Millisecond * 50)
+
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err = range errs {
+		if err != nil {
+			t.Fatalf("invalid error, expected nil and received %v", err)
+		}
+	}
+
+	if err = c.Batch(context.Background(), func(txn *Transaction) (err error) {
+		return
+	}); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	if _, err = c.New(&foobar); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	if err = c.Close(); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(c)
+
+	var count int
+	if err = c.ForEachID(func(entryID string) (err error) {
+		count++
+		return
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 4 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 4, count)
+	}
+}
+
+func TestMojura_Close_transactions(t *testing.T) {
+	var (
+		c   *Mojura
+		err error
+	)
+
+	if c, err = testInit(); err != nil {
+		testTeardown(c)
+		t.Fatal(err)
+	}
+	defer testTeardown(nil)
+
+	foobar := makeTestStruct("user_1", "contact_1", "group_1", "FOO FOO")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	txnErr := make(chan error, 1)
+	go func() {
+		txnErr <- c.Transaction(context.Background(), func(txn *Transaction) (err error) {
+			close(started)
+			<-release
+			_, err = txn.New(&foobar)
+			return
+		})
+	}()
+
+	<-started
+
+	closeErr := make(chan error, 1)
+	go func() {
+		closeErr <- c.Close()
+	}()
+
+	// Wait for the close to begin
+	for !c.closed.Get() {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err = c.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		return
+	}); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	select {
+	case err = <-closeErr:
+		t.Fatalf("invalid close state, expected close to wait for in-flight transaction and received %v", err)
+	default:
+	}
+
+	close(release)
+
+	if err = <-txnErr; err != nil {
+		t.Fatal(err)
+	}
+
+	if err = <-closeErr; err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var count int
+	if err = c.ForEachID(func(entryID string) (err error) {
+		count++
+		return
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 1, count)
+	}
+}
+
 func TestMojura_index_increment_persist(t *testing.T) {
 	var (
 		c   *Mojura
@@ -1416,11 +2067,51 @@ func ExampleMojura_Remove() {
 }
 
 func testInit() (c *Mojura, err error) {
+	return testInitWithOpts(defaultOpts)
+}
+
+func testInitWithOpts(opts Opts) (c *Mojura, err error) {
 	if err = os.MkdirAll(testDir, 0744); err != nil {
 		return
 	}
 
-	return New("test", testDir, &testStruct{}, "users", "contacts", "groups", "tags")
+	return NewWithOpts("test", testDir, &testStruct{}, opts, "users", "contacts", "groups", "tags")
+}
+
+const errTestTransient = errors.Error("transient backend error")
+
+// testFailingBackend will fail commits of write transactions with errTestTransient
+type testFailingBackend struct {
+	backend.Backend
+
+	mux sync.Mutex
+	// Number of commits to fail, a negative value fails every commit
+	failures int
+}
+
+func (b *testFailingBackend) Transaction(fn func(backend.Transaction) error) (err error) {
+	return b.Backend.Transaction(func(txn backend.Transaction) (err error) {
+		if err = fn(txn); err != nil {
+			return
+		}
+
+		return b.fail()
+	})
+}
+
+func (b *testFailingBackend) fail() (err error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.failures == 0 {
+		return
+	}
+
+	if b.failures > 0 {
+		b.failures--
+	}
+
+	// Returning an error will rollback the transaction
+	return errTestTransient
 }
 
 func testTeardown(c *Mojura) (err error) {
@@ -1433,6 +2124,46 @@ func testTeardown(c *Mojura) (err error) {
 	return errs.Err()
 }
 
+type testFilteredCase struct {
+	filters  []Filter
+	reverse  bool
+	expected []string
+}
+
+func testFilteredCases(t *testing.T, m *Mojura, tcs []testFilteredCase) {
+	for i, tc := range tcs {
+		o := NewFilteringOpts(tc.filters...)
+		o.Reverse = tc.reverse
+
+		var (
+			filtered []*testStruct
+			err      error
+		)
+
+		if _, err = m.GetFiltered(&filtered, o); err != nil && err != ErrEntryNotFound {
+			t.Fatalf("test case #%d: %v", i, err)
+		}
+
+		if err = testCheckIDs(filtered, tc.expected); err != nil {
+			t.Fatalf("test case #%d: %v", i, err)
+		}
+	}
+}
+
+func testCheckIDs(entries []*testStruct, expected []string) (err error) {
+	if len(entries) != len(expected) {
+		return fmt.Errorf("invalid number of entries, expected %d and received %d", len(expected), len(entries))
+	}
+
+	for i, entry := range entries {
+		if entry.ID != expected[i] {
+			return fmt.Errorf("invalid ID at index %d, expected <%s> and received <%s>", i, expected[i], entry.ID)
+		}
+	}
+
+	return
+}
+
 func testCheck(a, b *testStruct) (err error) {
 	if a.ID != b.ID {
 		return fmt.Errorf("invalid id, expected %s and received %s", a.ID, b.ID)
@@ -1486,6 +2217,27 @@ func (t *testStruct) GetRelationships() (r Relationships) {
 	return
 }
 
+func (t *testStruct) GetSearchFields() []string {
+	return []string{t.Value}
+}
+
+func (t *testStruct) UnsetReference(relationshipKey, relationshipID string) {
+	switch relationshipKey {
+	case "users":
+		if t.UserID == relationshipID {
+			t.UserID = ""
+		}
+	case "contacts":
+		if t.ContactID == relationshipID {
+			t.ContactID = ""
+		}
+	case "groups":
+		if t.GroupID == relationshipID {
+			t.GroupID = ""
+		}
+	}
+}
+
 type testBadType struct {
 	Foo string
 	Bar string
@@ -2,9 +2,9 @@ package mojura
 
 var _ Cursor = &multiCursor{}
 
-func newMultiCursor(txn *Transaction, fs []Filter) (c Cursor, err error) {
+func newMultiCursorFromPlan(txn *Transaction, p queryPlan) (c Cursor, err error) {
 	var m multiCursor
-	if m.mid, err = newMultiIDCursor(txn, fs); err != nil {
+	if m.mid, err = newMultiIDCursorFromPlan(txn, p); err != nil {
 		return
 	}
 
@@ -117,15 +117,30 @@ func (c *multiCursor) HasReverse(entryID string) (ok bool, err error) {
 	return c.mid.HasReverse(entryID)
 }
 
-func (c *multiCursor) get(entryID []byte) (val Value, err error) {
-	var bs []byte
-	// Attempt to get and associate bytes to value
-	if bs, err = c.txn.getBytes(entryID); err != nil {
+// seekPast will return the first entry past the provided position in the provided direction
+// Note: The entry at the position does not need to exist
+func (c *multiCursor) seekPast(relationshipID, entryID string, reverse bool) (val Value, err error) {
+	var seekedID []byte
+	seekedID, err = c.mid.seekTo([]byte(relationshipID), []byte(entryID))
+	switch {
+	case err == Break && reverse:
+		// No entries exist at or after the position, the last entry precedes it
+		return c.Last()
+	case err != nil:
 		return
+	case reverse:
+		// Seek will land on the first entry at or after the position
+		return c.Prev()
+	case string(seekedID) == entryID && c.getCurrentRelationshipID() == relationshipID:
+		// Seek landed on the position, move past it
+		return c.Next()
 	}
 
-	// Set value from bytes
-	return c.txn.m.newValueFromBytes(bs)
+	return c.get(seekedID)
+}
+
+func (c *multiCursor) get(entryID []byte) (val Value, err error) {
+	return c.mid.get(entryID)
 }
 
 func (c *multiCursor) getCurrentRelationshipID() (relationshipID string) {
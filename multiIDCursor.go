@@ -1,45 +1,80 @@
 package mojura
 
+import "github.com/mojura/mojura/filters"
+
 var _ IDCursor = &multiIDCursor{}
 
-func newMultiIDCursor(txn *Transaction, fs []Filter) (mp *multiIDCursor, err error) {
+func newMultiIDCursorFromPlan(txn *Transaction, p queryPlan) (mp *multiIDCursor, err error) {
+	return newMultiIDCursorWithFn(txn, p, newPlannedFilterCursor)
+}
+
+func newMultiIDCursorWithFn(txn *Transaction, p queryPlan, fn func(txn *Transaction, f Filter, distinct, reverse bool) (filterCursor, error)) (mp *multiIDCursor, err error) {
 	var m multiIDCursor
-	if len(fs) == 0 {
-		err = ErrEmptyFilters
+	if m.primary, err = fn(txn, p.primary, p.distinct, p.reverse); err != nil {
 		return
 	}
 
-	fcs := make([]filterCursor, 0, len(fs))
-	for _, f := range fs {
+	m.secondary = make([]filterCursor, 0, len(p.secondary))
+	for _, f := range p.secondary {
 		var fc filterCursor
-		if fc, err = newFilterCursor(txn, f); err != nil {
+		// Secondary filters are only probed, so they do not need to be distinct
+		if fc, err = fn(txn, f, false, false); err != nil {
 			return
 		}
 
-		fcs = append(fcs, fc)
+		m.secondary = append(m.secondary, fc)
 	}
 
-	m.txn = txn
-	m.primary = fcs[0]
-	if len(fs) > 1 {
-		m.secondary = fcs[1:]
+	m.predicates = make([]filters.PredicateFn, 0, len(p.predicates))
+	for _, predicate := range p.predicates {
+		m.predicates = append(m.predicates, predicate.Predicate)
 	}
 
+	m.txn = txn
+	m.pin = p.getPin()
 	mp = &m
 	return
 }
 
+// newPlannedFilterCursor will return the filter cursor of a planned filter
+// Note: When distinct, entries are positioned at their first matching relationship ID in the provided direction
+func newPlannedFilterCursor(txn *Transaction, f Filter, distinct, reverse bool) (fc filterCursor, err error) {
+	if f == nil {
+		// No primary filter is available, scan all entries
+		return newEntriesFilterCursor(txn)
+	}
+
+	if fc, err = newFilterCursor(txn, f); err != nil {
+		return
+	}
+
+	if dc, ok := fc.(distinctCursor); ok && distinct {
+		dc.enableDistinct(reverse)
+	}
+
+	return
+}
+
 type multiIDCursor struct {
 	txn *Transaction
 
-	primary   filterCursor
-	secondary []filterCursor
+	primary    filterCursor
+	secondary  []filterCursor
+	predicates []filters.PredicateFn
+	// Index of the primary when it is pinned, -1 otherwise (see queryPlan.getPin)
+	pin int
+
+	// Last entry decoded by the predicates, kept to avoid decoding matched entries twice
+	decoded   Value
+	decodedID string
 }
 
 func (c *multiIDCursor) teardown() {
 	c.txn = nil
 	c.primary = nil
 	c.secondary = nil
+	c.predicates = nil
+	c.decoded = nil
 }
 
 func (c *multiIDCursor) getCurrentRelationshipID() (relationshipID string) {
@@ -58,7 +93,7 @@ func (c *multiIDCursor) isForwardMatch(entryID []byte) (isMatch bool, err error)
 		}
 	}
 
-	return true, nil
+	return c.isPredicateMatch(entryID)
 }
 
 func (c *multiIDCursor) isReverseMatch(entryID []byte) (isMatch bool, err error) {
@@ -73,7 +108,59 @@ func (c *multiIDCursor) isReverseMatch(entryID []byte) (isMatch bool, err error)
 		}
 	}
 
-	return true, nil
+	return c.isPredicateMatch(entryID)
+}
+
+func (c *multiIDCursor) isPredicateMatch(entryID []byte) (isMatch bool, err error) {
+	if len(c.predicates) == 0 {
+		return true, nil
+	}
+
+	var val Value
+	if val, err = c.get(entryID); err != nil {
+		return
+	}
+
+	for _, predicate := range c.predicates {
+		if isMatch, err = predicate(val); err != nil {
+			isMatch = false
+			return
+		}
+
+		if !isMatch {
+			return
+		}
+	}
+
+	return
+}
+
+// get will return the decoded entry for the provided entry ID
+// Note: The entry decoded by the predicates is reused when available
+func (c *multiIDCursor) get(entryID []byte) (val Value, err error) {
+	if c.decoded != nil && c.decodedID == string(entryID) {
+		val = c.decoded
+		c.decoded = nil
+		return
+	}
+
+	var bs []byte
+	// Attempt to get and associate bytes to value
+	if bs, err = c.txn.getBytes(entryID); err != nil {
+		return
+	}
+
+	// Set value from bytes
+	if val, err = c.txn.m.newValueFromBytes(bs); err != nil {
+		return
+	}
+
+	if len(c.predicates) > 0 {
+		c.decoded = val
+		c.decodedID = string(entryID)
+	}
+
+	return
 }
 
 func (c *multiIDCursor) nextUntilMatch(entryID []byte) (matchEntryID []byte, err error) {
@@ -120,7 +207,12 @@ func (c *multiIDCursor) seek(seekID []byte) (entryID []byte, err error) {
 		return
 	}
 
-	if entryID, err = c.primary.SeekForward(relationshipKey, seekID); err != nil {
+	return c.seekTo(relationshipKey, seekID)
+}
+
+// seekTo will seek the provided relationship ID and entry ID without the need of a joined seek ID
+func (c *multiIDCursor) seekTo(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if entryID, err = c.primary.SeekForward(relationshipID, seekID); err != nil {
 		return
 	}
 
@@ -278,7 +370,7 @@ func (c *multiIDCursor) HasForward(entryID string) (ok bool, err error) {
 		}
 	}
 
-	return
+	return c.isPredicateMatch([]byte(entryID))
 }
 
 // HasReverse will determine if an entry exists in a reverse direction
@@ -297,5 +389,5 @@ func (c *multiIDCursor) HasReverse(entryID string) (ok bool, err error) {
 		}
 	}
 
-	return
+	return c.isPredicateMatch([]byte(entryID))
 }
@@ -0,0 +1,21 @@
+package mojura
+
+import "github.com/mojura/backend"
+
+var (
+	nopR backend.Transaction = &nopRoot{}
+)
+
+// nopRoot is used when a namespaced root bucket does not exist
+type nopRoot struct{}
+
+// GetBucket will return a nil bucket
+func (n *nopRoot) GetBucket(key []byte) (bkt backend.Bucket) {
+	return
+}
+
+// GetOrCreateBucket will return ErrNotInitialized
+func (n *nopRoot) GetOrCreateBucket(key []byte) (bkt backend.Bucket, err error) {
+	err = ErrNotInitialized
+	return
+}
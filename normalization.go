@@ -0,0 +1,228 @@
+package mojura
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization represents the normalization applied to the relationship IDs of a relationship key
+// Note: Normalization is applied when relationship IDs are indexed and when filters are matched against
+// the index, the stored entry values are left untouched
+type Normalization struct {
+	// FoldCase will match relationship IDs regardless of their casing (e.g. "Alice@x.com" and "alice@x.com")
+	FoldCase bool `json:"foldCase"`
+	// NFC will match relationship IDs by their Unicode Normalization Form C (e.g. "é" and "é")
+	NFC bool `json:"nfc"`
+	// Trim will match relationship IDs without their leading and trailing white space
+	Trim bool `json:"trim"`
+}
+
+// Normalize will return the normalized form of a relationship ID
+func (n Normalization) Normalize(relationshipID string) (normalized string) {
+	normalized = relationshipID
+	if n.Trim {
+		normalized = strings.TrimSpace(normalized)
+	}
+
+	if n.FoldCase {
+		normalized = cases.Fold().String(normalized)
+	}
+
+	if n.NFC {
+		normalized = norm.NFC.String(normalized)
+	}
+
+	return
+}
+
+// NormalizeAll will return the normalized form of a set of relationship IDs
+func (n Normalization) NormalizeAll(relationshipIDs []string) (normalized []string) {
+	normalized = make([]string, 0, len(relationshipIDs))
+	for _, relationshipID := range relationshipIDs {
+		normalized = append(normalized, n.Normalize(relationshipID))
+	}
+
+	return
+}
+
+// IsZero will return whether or not the normalization leaves relationship IDs untouched
+func (n Normalization) IsZero() (isZero bool) {
+	return n == Normalization{}
+}
+
+// flags will return the stored form of the normalization, used to detect when an index needs to be rebuilt
+func (n Normalization) flags() (flags int64) {
+	for i, enabled := range []bool{n.FoldCase, n.NFC, n.Trim} {
+		if enabled {
+			flags |= 1 << i
+		}
+	}
+
+	return
+}
+
+// getNormalizations will return the normalization of each relationship, in the order of the relationships
+func getNormalizations(byKey map[string]Normalization, relationships []string) (normalizations []Normalization, err error) {
+	normalizations = make([]Normalization, len(relationships))
+	for relationshipKey, n := range byKey {
+		index := -1
+		for i, relationship := range relationships {
+			if relationship == relationshipKey {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			err = fmt.Errorf("%w <%s>", ErrInvalidNormalization, relationshipKey)
+			return
+		}
+
+		normalizations[index] = n
+	}
+
+	return
+}
+
+// initNormalization will rebuild the index of a relationship when its normalization has changed
+func (m *Mojura) initNormalization(root backend.Transaction, relationshipsBkt, missingBkt, countsBkt, statsBkt backend.Bucket, relationship []byte, index int) (err error) {
+	statKey := getNormalizationStatKey(relationship)
+	flags := m.normalizations[index].flags()
+	if getCount(statsBkt, statKey) == flags {
+		// Index was created with the current normalization
+		return
+	}
+
+	// Remove the index (and its counts) which was created with the previous normalization
+	if err = deleteBucketIfExists(relationshipsBkt, relationship); err != nil {
+		return
+	}
+
+	if err = deleteBucketIfExists(countsBkt, relationship); err != nil {
+		return
+	}
+
+	// Relationship IDs may normalize to empty, so the missing index is re-created by initMissingBucket
+	if err = deleteBucketIfExists(missingBkt, relationship); err != nil {
+		return
+	}
+
+	var relationshipBkt backend.Bucket
+	if relationshipBkt, err = relationshipsBkt.GetOrCreateBucket(relationship); err != nil {
+		return
+	}
+
+	if err = root.GetBucket(entriesBktKey).ForEach(func(entryID, bs []byte) (err error) {
+		var val Value
+		if val, err = m.newValueFromBytes(bs); err != nil {
+			return
+		}
+
+		relationships := m.normalizeRelationships(val.GetRelationships())
+		if index >= len(relationships) {
+			return
+		}
+
+		for _, relationshipID := range relationships[index] {
+			if len(relationshipID) == 0 {
+				continue
+			}
+
+			if err = putRelationshipKey(relationshipBkt, []byte(relationshipID), entryID); err != nil {
+				return
+			}
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	// Counts are re-created by initCountsBucket
+	return putCount(statsBkt, statKey, flags)
+}
+
+// normalizeRelationships will return the relationships with their relationship IDs normalized
+func (m *Mojura) normalizeRelationships(relationships Relationships) (normalized Relationships) {
+	normalized = make(Relationships, 0, len(relationships))
+	for i, relationship := range relationships {
+		if i >= len(m.normalizations) || m.normalizations[i].IsZero() {
+			normalized = append(normalized, relationship)
+			continue
+		}
+
+		normalized = append(normalized, m.normalizations[i].NormalizeAll(relationship))
+	}
+
+	return
+}
+
+// getNormalization will return the normalization of a relationship key
+// Note: A zero normalization is returned for unknown and system relationship keys
+func (m *Mojura) getNormalization(relationshipKey string) (n Normalization) {
+	for i, relationship := range m.relationships {
+		if string(relationship) == relationshipKey {
+			return m.normalizations[i]
+		}
+	}
+
+	return
+}
+
+// normalizeFilter will return a copy of the filter with its relationship IDs normalized
+// Note: Filters whose relationship keys are not normalized are returned as is
+func (m *Mojura) normalizeFilter(f Filter) (normalized Filter) {
+	switch n := f.(type) {
+	case *filters.MatchFilter:
+		if normalization := m.getNormalization(n.RelationshipKey); !normalization.IsZero() {
+			return filters.Match(n.RelationshipKey, normalization.Normalize(n.RelationshipID))
+		}
+	case *filters.InverseMatchFilter:
+		if normalization := m.getNormalization(n.RelationshipKey); !normalization.IsZero() {
+			return filters.InverseMatch(n.RelationshipKey, normalization.Normalize(n.RelationshipID))
+		}
+	case *filters.InFilter:
+		if normalization := m.getNormalization(n.RelationshipKey); !normalization.IsZero() {
+			return filters.In(n.RelationshipKey, normalization.NormalizeAll(n.RelationshipIDs)...)
+		}
+	case *filters.NotInFilter:
+		if normalization := m.getNormalization(n.RelationshipKey); !normalization.IsZero() {
+			return filters.NotIn(n.RelationshipKey, normalization.NormalizeAll(n.RelationshipIDs)...)
+		}
+	case *filters.PrefixFilter:
+		if normalization := m.getNormalization(n.RelationshipKey); !normalization.IsZero() {
+			return filters.Prefix(n.RelationshipKey, normalization.Normalize(n.Prefix))
+		}
+	case *filters.ComparisonFilter:
+		if normalization := m.getNormalization(n.RelationshipKey); !normalization.IsZero() {
+			return normalizeComparison(n, normalization)
+		}
+	}
+
+	return f
+}
+
+// normalizeComparison will return a copy of the comparison with its value and range normalized
+// Note: Comparisons with custom comparison funcs are returned as is
+func normalizeComparison(c *filters.ComparisonFilter, n Normalization) (normalized *filters.ComparisonFilter) {
+	switch c.Operator {
+	case filters.OperatorLessThan:
+		return filters.LessThan(c.RelationshipKey, n.Normalize(c.Value))
+	case filters.OperatorLessThanOrEqualTo:
+		return filters.LessThanOrEqualTo(c.RelationshipKey, n.Normalize(c.Value))
+	case filters.OperatorGreaterThan:
+		return filters.GreaterThan(c.RelationshipKey, n.Normalize(c.Value))
+	case filters.OperatorGreaterThanOrEqualTo:
+		return filters.GreaterThanOrEqualTo(c.RelationshipKey, n.Normalize(c.Value))
+	case filters.OperatorBetween:
+		return filters.Range(c.RelationshipKey, n.Normalize(c.RangeStart), n.Normalize(c.RangeEnd))
+
+	default:
+		return c
+	}
+}
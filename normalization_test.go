@@ -0,0 +1,206 @@
+package mojura
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func TestNormalization_Normalize(t *testing.T) {
+	type testcase struct {
+		normalization  Normalization
+		relationshipID string
+		expected       string
+	}
+
+	tcs := []testcase{
+		{normalization: Normalization{}, relationshipID: " Alice@X.com ", expected: " Alice@X.com "},
+		{normalization: Normalization{Trim: true}, relationshipID: " Alice@X.com\t", expected: "Alice@X.com"},
+		{normalization: Normalization{FoldCase: true}, relationshipID: "Alice@X.com", expected: "alice@x.com"},
+		{normalization: Normalization{FoldCase: true}, relationshipID: "STRASSE", expected: "strasse"},
+		{normalization: Normalization{NFC: true}, relationshipID: "café", expected: "café"},
+		{normalization: Normalization{FoldCase: true, NFC: true, Trim: true}, relationshipID: " CAFÉ ", expected: "café"},
+	}
+
+	for _, tc := range tcs {
+		if normalized := tc.normalization.Normalize(tc.relationshipID); normalized != tc.expected {
+			t.Fatalf("invalid normalized value for <%s>, expected <%s> and received <%s>", tc.relationshipID, tc.expected, normalized)
+		}
+	}
+}
+
+func TestMojura_Normalizations(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.Normalizations = map[string]Normalization{
+		"users": {FoldCase: true, NFC: true, Trim: true},
+	}
+
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	userIDs := []string{"Alice@X.com", " alice@x.com ", "Bob", "René", "rené"}
+	for _, userID := range userIDs {
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Match("users", "ALICE@x.com")},
+			expected: []string{"00000000", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.In("users", "bob ", "RENÉ")},
+			expected: []string{"00000002", "00000003", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.Prefix("users", "AL")},
+			expected: []string{"00000000", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.InverseMatch("users", "alice@X.COM")},
+			expected: []string{"00000002", "00000003", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.GreaterThanOrEqualTo("users", "BOB")},
+			expected: []string{"00000002", "00000003", "00000004"},
+		},
+		{
+			// Relationships without normalization are matched byte-for-byte
+			filters:  []Filter{filters.Match("contacts", "CONTACT_0")},
+			expected: []string{},
+		},
+	})
+
+	// Stored entry values are left untouched
+	var entry testStruct
+	if err = m.Get("00000001", &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.UserID != " alice@x.com " {
+		t.Fatalf("invalid user ID, expected <%s> and received <%s>", " alice@x.com ", entry.UserID)
+	}
+
+	// Edits which only change the casing keep the entry indexed
+	entry.UserID = "ALICE@X.COM"
+	if err = m.Edit("00000001", &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Remove("00000000"); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Match("users", "alice@x.com")},
+			expected: []string{"00000001"},
+		},
+	})
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Removing the normalization will rebuild the index byte-for-byte
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Match("users", "alice@x.com")},
+			expected: []string{},
+		},
+		{
+			filters:  []Filter{filters.Match("users", "ALICE@X.COM")},
+			expected: []string{"00000001"},
+		},
+	})
+}
+
+func TestMojura_Normalizations_missing(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { testTeardown(m) }()
+
+	if _, err = m.New(newTestStruct("  ", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Whitespace-only relationship IDs are not missing without normalization
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.MissingRelationship("users")},
+			expected: []string{},
+		},
+	})
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Adding the normalization will rebuild the missing index from the normalized relationship IDs
+	opts := defaultOpts
+	opts.Normalizations = map[string]Normalization{
+		"users": {Trim: true},
+	}
+
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = m.New(newTestStruct("\t", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.MissingRelationship("users")},
+			expected: []string{"00000000", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.HasRelationship("users")},
+			expected: []string{"00000001"},
+		},
+	})
+
+	if err = m.Edit("00000000", newTestStruct("user_1", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.MissingRelationship("users")},
+			expected: []string{"00000002"},
+		},
+	})
+}
+
+func TestNew_invalid_normalization(t *testing.T) {
+	opts := defaultOpts
+	opts.Normalizations = map[string]Normalization{"emails": {FoldCase: true}}
+	if _, err := newMojura(&testStruct{}, opts, []string{"users", "contacts", "groups", "tags"}); !errors.Is(err, ErrInvalidNormalization) {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidNormalization, err)
+	}
+}
@@ -0,0 +1,169 @@
+package mojura
+
+import (
+	"github.com/mojura/mojura/filters"
+)
+
+var _ filterCursor = &notCursor{}
+
+func newNotCursor(txn *Transaction, f *filters.NotFilter) (c filterCursor, err error) {
+	var not notCursor
+	not.txn = txn
+	if not.entries, err = newEntriesFilterCursor(txn); err != nil {
+		return
+	}
+
+	if not.child, err = newFilterCursor(txn, f.Filter); err != nil {
+		return
+	}
+
+	switch {
+	case not.child == nopC:
+		// Child cannot match any entries, all entries match
+		c = not.entries
+		return
+	case !isEntryOrdered(not.child):
+		// Child is probed for every entry, collect its entry IDs in entry ID order so
+		// each probe is a lookup rather than an iteration of the child
+		if not.child, err = newEntryOrderedCursor(txn, not.child); err != nil {
+			return
+		}
+	}
+
+	c = &not
+	return
+}
+
+// notCursor iterates through all entries which do not match its child
+type notCursor struct {
+	txn *Transaction
+
+	entries filterCursor
+	child   filterCursor
+}
+
+func (c *notCursor) isMatch(entryID []byte, reverse bool) (ok bool, err error) {
+	if reverse {
+		ok, err = c.child.HasReverse(entryID)
+	} else {
+		ok, err = c.child.HasForward(entryID)
+	}
+
+	switch err {
+	case nil:
+		ok = !ok
+	case Break:
+		// Child has no entries to match against
+		ok, err = true, nil
+	}
+
+	return
+}
+
+func (c *notCursor) nextUntilMatch(entryID []byte, iteratingErr error) (matchingEntryID []byte, err error) {
+	var ok bool
+	for err = iteratingErr; err == nil; entryID, err = c.entries.Next() {
+		if ok, err = c.isMatch(entryID, false); err != nil {
+			return
+		}
+
+		if ok {
+			matchingEntryID = entryID
+			return
+		}
+	}
+
+	return
+}
+
+func (c *notCursor) prevUntilMatch(entryID []byte, iteratingErr error) (matchingEntryID []byte, err error) {
+	var ok bool
+	for err = iteratingErr; err == nil; entryID, err = c.entries.Prev() {
+		if ok, err = c.isMatch(entryID, true); err != nil {
+			return
+		}
+
+		if ok {
+			matchingEntryID = entryID
+			return
+		}
+	}
+
+	return
+}
+
+func (c *notCursor) getCurrentRelationshipID() (relationshipID string) {
+	return ""
+}
+
+// SeekForward will seek the provided ID in a forward direction
+func (c *notCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.nextUntilMatch(c.entries.SeekForward(relationshipID, seekID))
+}
+
+// SeekReverse will seek the provided ID in a reverse direction
+func (c *notCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.prevUntilMatch(c.entries.SeekReverse(relationshipID, seekID))
+}
+
+// First will return the first entry
+func (c *notCursor) First() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.nextUntilMatch(c.entries.First())
+}
+
+// Last will return the last entry
+func (c *notCursor) Last() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.prevUntilMatch(c.entries.Last())
+}
+
+// Next will return the next entry
+func (c *notCursor) Next() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.nextUntilMatch(c.entries.Next())
+}
+
+// Prev will return the previous entry
+func (c *notCursor) Prev() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.prevUntilMatch(c.entries.Prev())
+}
+
+// HasForward will determine if an entry exists in a forward direction
+func (c *notCursor) HasForward(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.isMatch(entryID, false)
+}
+
+// HasReverse will determine if an entry exists in a reverse direction
+func (c *notCursor) HasReverse(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.isMatch(entryID, true)
+}
@@ -0,0 +1,172 @@
+package mojura
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func Test_notCursor(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if err = testInsertNestedEntries(m); err != nil {
+		t.Fatal(err)
+	}
+
+	tcs := []testFilteredCase{
+		{
+			// Unlike inverse match filters, entries without tags are included
+			filters:  []Filter{filters.Not(filters.Match("tags", "a"))},
+			expected: []string{"00000001", "00000002", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.Not(filters.Match("tags", "a"))},
+			reverse:  true,
+			expected: []string{"00000004", "00000002", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Not(filters.And(filters.Match("groups", "group_1"), filters.Match("contacts", "contact_1")))},
+			expected: []string{"00000000", "00000001", "00000003", "00000004"},
+		},
+		{
+			filters: []Filter{
+				filters.Match("groups", "group_1"),
+				filters.Not(filters.Or(filters.Match("users", "user_1"), filters.GreaterThanOrEqualTo("tags", "c"))),
+			},
+			expected: []string{"00000002"},
+		},
+		{
+			filters:  []Filter{filters.Not(filters.GreaterThan("tags", "a"))},
+			reverse:  true,
+			expected: []string{"00000001", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.Not(filters.Match("users", "user_404"))},
+			expected: []string{"00000000", "00000001", "00000002", "00000003", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.Not(filters.Not(filters.Match("users", "user_1")))},
+			expected: []string{"00000001", "00000003"},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
+func Test_notCursor_pagination(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if err = testInsertNestedEntries(m); err != nil {
+		t.Fatal(err)
+	}
+
+	type testcase struct {
+		reverse  bool
+		expected [][]string
+	}
+
+	tcs := []testcase{
+		{
+			expected: [][]string{{"00000001", "00000002"}, {"00000004"}},
+		},
+		{
+			reverse:  true,
+			expected: [][]string{{"00000004", "00000002"}, {"00000001"}},
+		},
+	}
+
+	for i, tc := range tcs {
+		o := NewFilteringOpts(filters.Not(filters.Match("tags", "a")))
+		o.Limit = 2
+		o.Reverse = tc.reverse
+
+		for j, page := range tc.expected {
+			var filtered []*testStruct
+			if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, j, err)
+			}
+
+			if err = testCheckIDs(filtered, page); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, j, err)
+			}
+		}
+	}
+}
+
+func Test_notCursor_iteration(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if err = testInsertNestedEntries(m); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		var cur filterCursor
+		if cur, err = newNotCursor(txn, filters.Not(filters.Match("tags", "a"))); err != nil {
+			return
+		}
+
+		steps := []struct {
+			fn       func() ([]byte, error)
+			expected string
+		}{
+			{fn: cur.First, expected: "00000001"},
+			{fn: cur.Next, expected: "00000002"},
+			{fn: cur.Prev, expected: "00000001"},
+			{fn: cur.Last, expected: "00000004"},
+			{fn: cur.Prev, expected: "00000002"},
+			{fn: func() ([]byte, error) { return cur.SeekForward(nil, []byte("00000003")) }, expected: "00000004"},
+			{fn: func() ([]byte, error) { return cur.SeekReverse(nil, []byte("00000003")) }, expected: "00000002"},
+		}
+
+		for i, step := range steps {
+			var entryID []byte
+			if entryID, err = step.fn(); err != nil {
+				return fmt.Errorf("step #%d: %v", i, err)
+			}
+
+			if string(entryID) != step.expected {
+				return fmt.Errorf("step #%d: invalid entry ID, expected <%s> and received <%s>", i, step.expected, entryID)
+			}
+		}
+
+		var ok bool
+		if ok, err = cur.HasReverse([]byte("00000003")); err != nil {
+			return
+		}
+
+		if ok {
+			return fmt.Errorf("invalid has value for <%s>, expected %v and received %v", "00000003", false, ok)
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,7 @@
 package mojura
 
 import (
+	"log"
 	"time"
 
 	"github.com/hatchify/errors"
@@ -17,6 +18,12 @@ const (
 	DefaultRetryBatchFail = true
 	// DefaultIndexLength is the default index length
 	DefaultIndexLength = 8
+	// DefaultBatchRetryAttempts is the default maximum number of times a batch call will be re-ran
+	DefaultBatchRetryAttempts = 16
+	// DefaultBatchRetryBackoff is the default initial backoff for a batch which failed due to a transient error
+	DefaultBatchRetryBackoff = time.Millisecond * 10
+	// DefaultBatchRetryMaxBackoff is the default maximum backoff for a batch which failed due to a transient error
+	DefaultBatchRetryMaxBackoff = time.Second
 )
 
 const (
@@ -28,19 +35,57 @@ var defaultOpts = Opts{
 	MaxBatchCalls:    DefaultMaxBatchCalls,
 	MaxBatchDuration: DefaultMaxBatchDuration,
 	RetryBatchFail:   DefaultRetryBatchFail,
+	BatchRetryPolicy: BatchRetryPolicy{
+		Strategy:    BatchRetryPrefix,
+		MaxAttempts: DefaultBatchRetryAttempts,
+		Backoff:     DefaultBatchRetryBackoff,
+		MaxBackoff:  DefaultBatchRetryMaxBackoff,
+	},
 
 	Initializer: bolt.New(),
 	Encoder:     &JSONEncoder{},
+	Logger:      log.Default(),
 }
 
 // Opts represent mojura options
 type Opts struct {
 	MaxBatchCalls    int
 	MaxBatchDuration time.Duration
+	// Deprecated: Use BatchRetryPolicy.MaxAttempts instead. When set and MaxAttempts is
+	// unset, MaxAttempts will be set as DefaultBatchRetryAttempts
 	RetryBatchFail   bool
+	BatchRetryPolicy BatchRetryPolicy
 
 	IndexLength int
 
+	// DisableQueryPlanner will always use the first filter of a query which is not an inverse filter as
+	// the primary filter (see IteratingOpts.PreserveFilterOrder)
+	DisableQueryPlanner bool
+
+	// IndexTimestamps will index the CreatedAt and UpdatedAt timestamps of entries so they can be
+	// queried with filters.CreatedBetween and filters.UpdatedSince
+	// Note: Existing entries are indexed on startup when enabled, and the indexes are removed when disabled
+	IndexTimestamps bool
+
+	// Normalizations represents the normalization of relationship IDs, keyed by relationship key
+	// Note: The index of a relationship is rebuilt on startup when its normalization changes
+	Normalizations map[string]Normalization
+
+	// IndexText will index the searchable fields of entries so they can be queried with filters.Text
+	// (see Searchable)
+	// Note: Existing entries are indexed on startup when enabled, and the index is removed when disabled
+	IndexText bool
+
+	// PageTokenKey is the key used to sign page tokens (see FilteringOpts.PageToken)
+	// Note: When unset, a key is generated and stored alongside the entries
+	PageTokenKey []byte
+
+	// SlowQueryThreshold is the duration after which filtered queries will be logged
+	// Note: When zero, slow queries are not logged
+	SlowQueryThreshold time.Duration
+	// Logger is used to log slow queries, defaults to the standard logger
+	Logger Logger
+
 	Initializer backend.Initializer
 	Encoder     Encoder
 }
@@ -48,7 +93,7 @@ type Opts struct {
 // Validate will validate a set of Options
 func (o *Opts) Validate() (err error) {
 	o.init()
-	return
+	return o.BatchRetryPolicy.Validate()
 }
 
 func (o *Opts) init() {
@@ -56,6 +101,10 @@ func (o *Opts) init() {
 		o.Encoder = defaultOpts.Encoder
 	}
 
+	if o.Logger == nil {
+		o.Logger = defaultOpts.Logger
+	}
+
 	if o.Initializer == nil {
 		o.Initializer = defaultOpts.Initializer
 	}
@@ -71,4 +120,16 @@ func (o *Opts) init() {
 	if o.IndexLength == 0 {
 		o.IndexLength = DefaultIndexLength
 	}
+
+	if o.BatchRetryPolicy.MaxAttempts == 0 && o.RetryBatchFail {
+		o.BatchRetryPolicy.MaxAttempts = DefaultBatchRetryAttempts
+	}
+
+	if o.BatchRetryPolicy.Backoff == 0 {
+		o.BatchRetryPolicy.Backoff = DefaultBatchRetryBackoff
+	}
+
+	if o.BatchRetryPolicy.MaxBackoff == 0 {
+		o.BatchRetryPolicy.MaxBackoff = DefaultBatchRetryMaxBackoff
+	}
 }
@@ -0,0 +1,320 @@
+package mojura
+
+import (
+	"bytes"
+
+	"github.com/mojura/mojura/filters"
+)
+
+var _ filterCursor = &orCursor{}
+
+func newOrCursor(txn *Transaction, f *filters.OrFilter) (c filterCursor, err error) {
+	return newMergedCursor(txn, f.Filters)
+}
+
+func newInCursor(txn *Transaction, f *filters.InFilter) (c filterCursor, err error) {
+	fs := make([]Filter, 0, len(f.RelationshipIDs))
+	for _, relationshipID := range f.RelationshipIDs {
+		fs = append(fs, filters.Match(f.RelationshipKey, relationshipID))
+	}
+
+	return newMergedCursor(txn, fs)
+}
+
+// newMergedCursor will return a cursor which merges the entries of the provided filters
+func newMergedCursor(txn *Transaction, fs []Filter) (c filterCursor, err error) {
+	var or orCursor
+	or.txn = txn
+	for _, childFilter := range fs {
+		var child filterCursor
+		if child, err = newFilterCursor(txn, childFilter); err != nil {
+			return
+		}
+
+		if child == nopC {
+			// Child cannot match any entries, no need to merge it
+			continue
+		}
+
+		if !isEntryOrdered(child) {
+			// Child iterates by relationship ID, collect its entry IDs in entry ID order
+			if child, err = newEntryOrderedCursor(txn, child); err != nil {
+				return
+			}
+		}
+
+		or.children = append(or.children, child)
+	}
+
+	if len(or.children) == 0 {
+		c = nopC
+		return
+	}
+
+	or.heads = make([][]byte, len(or.children))
+	c = &or
+	return
+}
+
+// orCursor merges the entries of its children in entry ID order. Entries which match
+// more than one child are only returned once
+type orCursor struct {
+	txn *Transaction
+
+	children []filterCursor
+	// Current entry ID for each child, nil when the child has been exhausted
+	heads [][]byte
+
+	current []byte
+	reverse bool
+}
+
+func (c *orCursor) setHead(index int, entryID []byte, err error) error {
+	switch err {
+	case nil:
+	case Break:
+		entryID = nil
+
+	default:
+		return err
+	}
+
+	c.heads[index] = entryID
+	return nil
+}
+
+// seekAfter will position a child at the first entry which is greater than the provided entry ID
+func (c *orCursor) seekAfter(child filterCursor, entryID []byte) (id []byte, err error) {
+	if id, err = child.SeekForward(nil, entryID); err != nil {
+		return
+	}
+
+	if bytes.Equal(id, entryID) {
+		return child.Next()
+	}
+
+	return
+}
+
+// seekBefore will position a child at the last entry which is less than the provided entry ID
+func (c *orCursor) seekBefore(child filterCursor, entryID []byte) (id []byte, err error) {
+	switch id, err = child.SeekForward(nil, entryID); err {
+	case nil:
+		// Child is positioned at an entry greater than or equal to the entry ID, step back
+		return child.Prev()
+	case Break:
+		// No entries exist past the entry ID, the last entry is the closest
+		return child.Last()
+
+	default:
+		return
+	}
+}
+
+func (c *orCursor) each(fn func(child filterCursor) ([]byte, error)) (err error) {
+	for i, child := range c.children {
+		entryID, fnErr := fn(child)
+		if err = c.setHead(i, entryID, fnErr); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (c *orCursor) eachCurrent(fn func(child filterCursor) ([]byte, error)) (err error) {
+	for i, child := range c.children {
+		if !bytes.Equal(c.heads[i], c.current) {
+			continue
+		}
+
+		entryID, fnErr := fn(child)
+		if err = c.setHead(i, entryID, fnErr); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (c *orCursor) min() (entryID []byte, err error) {
+	for _, head := range c.heads {
+		if head == nil {
+			continue
+		}
+
+		if entryID == nil || bytes.Compare(head, entryID) == -1 {
+			entryID = head
+		}
+	}
+
+	return c.setCurrent(entryID)
+}
+
+func (c *orCursor) max() (entryID []byte, err error) {
+	for _, head := range c.heads {
+		if head == nil {
+			continue
+		}
+
+		if entryID == nil || bytes.Compare(head, entryID) == 1 {
+			entryID = head
+		}
+	}
+
+	return c.setCurrent(entryID)
+}
+
+func (c *orCursor) setCurrent(entryID []byte) ([]byte, error) {
+	if entryID == nil {
+		return nil, Break
+	}
+
+	c.current = entryID
+	return entryID, nil
+}
+
+func (c *orCursor) has(entryID []byte, reverse bool) (ok bool, err error) {
+	for _, child := range c.children {
+		if reverse {
+			ok, err = child.HasReverse(entryID)
+		} else {
+			ok, err = child.HasForward(entryID)
+		}
+
+		if err != nil || ok {
+			return
+		}
+	}
+
+	return
+}
+
+func (c *orCursor) getCurrentRelationshipID() (relationshipID string) {
+	return ""
+}
+
+// SeekForward will seek the provided ID
+func (c *orCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	c.reverse = false
+	if err = c.each(func(child filterCursor) ([]byte, error) {
+		return child.SeekForward(nil, seekID)
+	}); err != nil {
+		return
+	}
+
+	return c.min()
+}
+
+// SeekReverse will seek the provided ID
+func (c *orCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
+	// Seeking positions the cursor at the first entry greater than or equal to the seek ID,
+	// the following call to Prev will reposition the children for reverse iteration
+	return c.SeekForward(relationshipID, seekID)
+}
+
+// First will return the first entry
+func (c *orCursor) First() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	c.reverse = false
+	if err = c.each(filterCursor.First); err != nil {
+		return
+	}
+
+	return c.min()
+}
+
+// Last will return the last entry
+func (c *orCursor) Last() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	c.reverse = true
+	if err = c.each(filterCursor.Last); err != nil {
+		return
+	}
+
+	return c.max()
+}
+
+// Next will return the next entry
+func (c *orCursor) Next() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	if c.current == nil {
+		err = Break
+		return
+	}
+
+	if c.reverse {
+		// Changing direction, reposition all children after the current entry
+		c.reverse = false
+		err = c.each(func(child filterCursor) ([]byte, error) {
+			return c.seekAfter(child, c.current)
+		})
+	} else {
+		err = c.eachCurrent(filterCursor.Next)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return c.min()
+}
+
+// Prev will return the previous entry
+func (c *orCursor) Prev() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	if c.current == nil {
+		err = Break
+		return
+	}
+
+	if !c.reverse {
+		// Changing direction, reposition all children before the current entry
+		c.reverse = true
+		err = c.each(func(child filterCursor) ([]byte, error) {
+			return c.seekBefore(child, c.current)
+		})
+	} else {
+		err = c.eachCurrent(filterCursor.Prev)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return c.max()
+}
+
+// HasForward will determine if an entry exists in a forward direction
+func (c *orCursor) HasForward(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.has(entryID, false)
+}
+
+// HasReverse will determine if an entry exists in a reverse direction
+func (c *orCursor) HasReverse(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.has(entryID, true)
+}
@@ -0,0 +1,251 @@
+package mojura
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func Test_orCursor(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0"),
+		newTestStruct("user_1", "contact_2", "group_1", "1"),
+		newTestStruct("user_2", "contact_1", "group_1", "2"),
+		newTestStruct("user_1", "contact_1", "group_0", "3"),
+		newTestStruct("user_3", "contact_3", "group_1", "4"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_1"), filters.Match("contacts", "contact_1")),
+			},
+			expected: []string{"00000001", "00000002", "00000003"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_1"), filters.Match("contacts", "contact_1")),
+			},
+			reverse:  true,
+			expected: []string{"00000003", "00000002", "00000001"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_3"), filters.Match("users", "user_0"), filters.Match("users", "user_404")),
+			},
+			expected: []string{"00000000", "00000004"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_3"), filters.InverseMatch("groups", "group_1")),
+			},
+			expected: []string{"00000000", "00000003", "00000004"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_3"), filters.InverseMatch("groups", "group_1")),
+			},
+			reverse:  true,
+			expected: []string{"00000004", "00000003", "00000000"},
+		},
+		{
+			filters: []Filter{
+				filters.Match("groups", "group_1"),
+				filters.Or(filters.Match("users", "user_1"), filters.Match("contacts", "contact_3")),
+			},
+			expected: []string{"00000001", "00000004"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_1"), filters.Match("contacts", "contact_3")),
+				filters.Match("groups", "group_1"),
+			},
+			reverse:  true,
+			expected: []string{"00000004", "00000001"},
+		},
+		{
+			filters: []Filter{
+				filters.Or(filters.Match("users", "user_404")),
+			},
+			expected: []string{},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
+
+func Test_orCursor_pagination(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 10; i++ {
+		userID := fmt.Sprintf("user_%d", i%3)
+		contactID := fmt.Sprintf("contact_%d", i%2)
+		if _, err = m.New(newTestStruct(userID, contactID, "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Matches entries 0, 2, 3, 4, 6, 8 and 9
+	f := filters.Or(filters.Match("users", "user_0"), filters.Match("contacts", "contact_0"))
+
+	type testcase struct {
+		reverse  bool
+		expected [][]string
+	}
+
+	tcs := []testcase{
+		{
+			expected: [][]string{
+				{"00000000", "00000002", "00000003"},
+				{"00000004", "00000006", "00000008"},
+				{"00000009"},
+			},
+		},
+		{
+			reverse: true,
+			expected: [][]string{
+				{"00000009", "00000008", "00000006"},
+				{"00000004", "00000003", "00000002"},
+				{"00000000"},
+			},
+		},
+	}
+
+	for i, tc := range tcs {
+		o := NewFilteringOpts(f)
+		o.Limit = 3
+		o.Reverse = tc.reverse
+
+		for j, page := range tc.expected {
+			var filtered []*testStruct
+			if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, j, err)
+			}
+
+			if err = testCheckIDs(filtered, page); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, j, err)
+			}
+		}
+	}
+}
+
+func Test_orCursor_Has(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if err = m.Transaction(context.Background(), func(txn *Transaction) (err error) {
+		if _, err = txn.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+			return
+		}
+
+		if _, err = txn.New(newTestStruct("user_1", "contact_1", "group_1", "")); err != nil {
+			return
+		}
+
+		if _, err = txn.New(newTestStruct("user_2", "contact_2", "group_2", "")); err != nil {
+			return
+		}
+
+		var cur filterCursor
+		f := filters.Or(filters.Match("users", "user_0"), filters.Match("groups", "group_2"))
+		if cur, err = newOrCursor(txn, f); err != nil {
+			return
+		}
+
+		for id, expected := range map[string]bool{"00000000": true, "00000001": false, "00000002": true} {
+			var ok bool
+			if ok, err = cur.HasForward([]byte(id)); err != nil {
+				return
+			}
+
+			if ok != expected {
+				return fmt.Errorf("invalid has value for <%s>, expected %v and received %v", id, expected, ok)
+			}
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_inCursor(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0"),
+		newTestStruct("user_1", "contact_1", "group_1", "1"),
+		newTestStruct("user_2", "contact_0", "group_1", "2"),
+		newTestStruct("user_1", "contact_1", "group_0", "3"),
+		newTestStruct("user_3", "contact_0", "group_1", "4"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []testFilteredCase{
+		{
+			filters:  []Filter{filters.In("users", "user_1", "user_3", "user_404")},
+			expected: []string{"00000001", "00000003", "00000004"},
+		},
+		{
+			filters:  []Filter{filters.In("users", "user_1", "user_3", "user_1")},
+			reverse:  true,
+			expected: []string{"00000004", "00000003", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Match("groups", "group_1"), filters.In("users", "user_1", "user_2")},
+			expected: []string{"00000001", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.In("users", "user_404")},
+			expected: []string{},
+		},
+	}
+
+	testFilteredCases(t, m, tcs)
+}
@@ -0,0 +1,12 @@
+package mojura
+
+// OrderBy represents the relationship which determines the order of iteration
+type OrderBy struct {
+	// RelationshipKey represents the relationship whose relationship IDs the results are ordered by
+	// Note: Entries without a relationship ID for this key are not included in the results, and entries
+	// with multiple relationship IDs for this key will be included once per relationship ID unless
+	// IteratingOpts.Distinct is set
+	RelationshipKey string `json:"relationshipKey"`
+	// Descending will order the results from the greatest relationship ID to the least
+	Descending bool `json:"descending"`
+}
@@ -0,0 +1,107 @@
+package mojura
+
+import (
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func TestMojura_GetFiltered_OrderBy(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "", filters.Encode(30)),
+		newTestStruct("user_0", "contact_0", "group_0", "", filters.Encode(10)),
+		newTestStruct("user_1", "contact_0", "group_0", "", filters.Encode(20)),
+		newTestStruct("user_0", "contact_0", "group_0", "", filters.Encode(10)),
+		// Entries without a relationship ID for the order by key are not included
+		newTestStruct("user_0", "contact_0", "group_0", ""),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type testcase struct {
+		filters    []Filter
+		descending bool
+		reverse    bool
+		limit      int64
+		expected   [][]string
+	}
+
+	tcs := []testcase{
+		{
+			expected: [][]string{{"00000001", "00000003", "00000002", "00000000"}},
+		},
+		{
+			descending: true,
+			expected:   [][]string{{"00000000", "00000002", "00000003", "00000001"}},
+		},
+		{
+			descending: true,
+			reverse:    true,
+			expected:   [][]string{{"00000001", "00000003", "00000002", "00000000"}},
+		},
+		{
+			filters:  []Filter{filters.Match("users", "user_0")},
+			limit:    2,
+			expected: [][]string{{"00000001", "00000003"}, {"00000000"}},
+		},
+		{
+			filters:    []Filter{filters.Match("users", "user_0")},
+			descending: true,
+			limit:      2,
+			expected:   [][]string{{"00000000", "00000003"}, {"00000001"}},
+		},
+		{
+			filters:  []Filter{filters.InverseMatch("users", "user_0")},
+			expected: [][]string{{"00000002"}},
+		},
+	}
+
+	for i, tc := range tcs {
+		o := NewFilteringOpts(tc.filters...)
+		o.OrderBy = &OrderBy{RelationshipKey: "tags", Descending: tc.descending}
+		o.Reverse = tc.reverse
+		if tc.limit > 0 {
+			o.Limit = tc.limit
+		}
+
+		for page, expected := range tc.expected {
+			var filtered []*testStruct
+			if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, page, err)
+			}
+
+			if err = testCheckIDs(filtered, expected); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, page, err)
+			}
+		}
+
+		if o.LastID != "" {
+			t.Fatalf("test case #%d: invalid last ID, expected the final page to have an empty last ID and received <%s>", i, o.LastID)
+		}
+	}
+
+	var first testStruct
+	o := NewIteratingOpts(filters.Match("users", "user_0"))
+	o.OrderBy = &OrderBy{RelationshipKey: "tags", Descending: true}
+	if err = m.GetFirst(&first, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.ID != "00000000" {
+		t.Fatalf("invalid first entry, expected <%s> and received <%s>", "00000000", first.ID)
+	}
+}
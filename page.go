@@ -0,0 +1,10 @@
+package mojura
+
+// Page represents the pagination tokens of a page of filtered entries
+// Note: Tokens are set within FilteringOpts.PageToken to retrieve the corresponding page
+type Page struct {
+	// Next is the token for the page which follows the entries, empty when no more entries exist
+	Next string `json:"next"`
+	// Previous is the token for the page which precedes the entries, empty when the entries are the first page
+	Previous string `json:"previous"`
+}
@@ -0,0 +1,199 @@
+package mojura
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+const (
+	pageTokenVersion = 1
+	// Length of the filters hash within a page token
+	pageTokenHashLength = 8
+	// Length of the signature of a page token
+	pageTokenSignatureLength = 16
+)
+
+const (
+	pageTokenReverse = 1 << iota
+	pageTokenBackward
+	pageTokenPinned
+)
+
+var pageTokenKeyStatKey = []byte("pageTokenKey")
+
+// getOrCreatePageTokenKey will get the key used to sign page tokens, creating one if it does not exist
+func getOrCreatePageTokenKey(statsBkt backend.Bucket) (key []byte, err error) {
+	if key = statsBkt.Get(pageTokenKeyStatKey); len(key) > 0 {
+		// Values are only valid for the life of the transaction, copy the key
+		key = append([]byte(nil), key...)
+		return
+	}
+
+	key = make([]byte, 32)
+	if _, err = rand.Read(key); err != nil {
+		return
+	}
+
+	err = statsBkt.Put(pageTokenKeyStatKey, key)
+	return
+}
+
+// getPageTokenHash will return the hash which identifies the query a page token was created for
+func getPageTokenHash(o *FilteringOpts) (hash []byte, err error) {
+	var query string
+	if query, err = filters.Format(o.Filters...); err != nil {
+		if len(o.PageQueryID) == 0 {
+			// Filters without a text form cannot be distinguished from one another
+			err = ErrMissingPageQueryID
+			return
+		}
+
+		// Filters without a text form are identified by the page query ID
+		query = getPageTokenQuery(o.Filters)
+		err = nil
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%t\n%t", query, o.PageQueryID, o.PreserveFilterOrder, o.Distinct)
+	if o.OrderBy != nil {
+		fmt.Fprintf(h, "\n%s\n%t", o.OrderBy.RelationshipKey, o.OrderBy.Descending)
+	}
+
+	hash = h.Sum(nil)[:pageTokenHashLength]
+	return
+}
+
+// getPageTokenQuery will return the text form of the filters, omitting the filters which have no text form
+// Note: The Go representation of a filter is not used, as it contains function pointers which differ between builds
+func getPageTokenQuery(fs []Filter) (query string) {
+	parts := make([]string, len(fs))
+	for i, f := range fs {
+		var err error
+		if parts[i], err = filters.Format(f); err != nil {
+			parts[i] = "?"
+		}
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+// pageToken represents the decoded form of a page token
+type pageToken struct {
+	// Whether or not the query iterates in reverse
+	reverse bool
+	// Whether or not the page precedes the position
+	backward bool
+
+	hash []byte
+	// Index of the primary the position was created with, -1 when the primary is not pinned (see queryPlan.getPin)
+	pin int
+
+	relationshipID string
+	entryID        string
+}
+
+func (p *pageToken) hasPosition() (ok bool) {
+	return len(p.entryID) > 0
+}
+
+// isReverse will return whether or not the page is iterated in reverse
+func (p *pageToken) isReverse() (reverse bool) {
+	return p.reverse != p.backward
+}
+
+func (p *pageToken) marshal(key []byte) (token string) {
+	var flags byte
+	if p.reverse {
+		flags |= pageTokenReverse
+	}
+
+	if p.backward {
+		flags |= pageTokenBackward
+	}
+
+	if p.pin != -1 {
+		flags |= pageTokenPinned
+	}
+
+	bs := []byte{pageTokenVersion, flags}
+	bs = append(bs, p.hash...)
+	length := make([]byte, binary.MaxVarintLen64)
+	if p.pin != -1 {
+		bs = append(bs, length[:binary.PutUvarint(length, uint64(p.pin))]...)
+	}
+
+	bs = append(bs, length[:binary.PutUvarint(length, uint64(len(p.relationshipID)))]...)
+	bs = append(bs, p.relationshipID...)
+	bs = append(bs, p.entryID...)
+	bs = append(bs, signPageToken(key, bs)...)
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+func (p *pageToken) unmarshal(key []byte, token string) (err error) {
+	var bs []byte
+	if bs, err = base64.RawURLEncoding.DecodeString(token); err != nil {
+		return ErrInvalidPageToken
+	}
+
+	if len(bs) < 2+pageTokenHashLength+pageTokenSignatureLength {
+		return ErrInvalidPageToken
+	}
+
+	payload, signature := bs[:len(bs)-pageTokenSignatureLength], bs[len(bs)-pageTokenSignatureLength:]
+	if !hmac.Equal(signature, signPageToken(key, payload)) {
+		return ErrInvalidPageToken
+	}
+
+	if payload[0] != pageTokenVersion {
+		return ErrInvalidPageToken
+	}
+
+	p.reverse = payload[1]&pageTokenReverse != 0
+	p.backward = payload[1]&pageTokenBackward != 0
+	p.hash = payload[2 : 2+pageTokenHashLength]
+
+	position := payload[2+pageTokenHashLength:]
+	p.pin = -1
+	if payload[1]&pageTokenPinned != 0 {
+		pin, n := binary.Uvarint(position)
+		if n <= 0 || pin > math.MaxInt32 {
+			return ErrInvalidPageToken
+		}
+
+		p.pin = int(pin)
+		position = position[n:]
+	}
+
+	length, n := binary.Uvarint(position)
+	if n <= 0 || uint64(len(position)-n) < length {
+		return ErrInvalidPageToken
+	}
+
+	p.relationshipID = string(position[n : n+int(length)])
+	p.entryID = string(position[n+int(length):])
+	return
+}
+
+func (p *pageToken) validate(hash []byte) (err error) {
+	if !bytes.Equal(p.hash, hash) {
+		return ErrPageTokenMismatch
+	}
+
+	return
+}
+
+func signPageToken(key, payload []byte) (signature []byte) {
+	h := hmac.New(sha256.New, key)
+	h.Write(payload)
+	return h.Sum(nil)[:pageTokenSignatureLength]
+}
@@ -0,0 +1,336 @@
+package mojura
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func TestMojura_GetPage(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 6; i++ {
+		userID := "user_0"
+		if i == 2 {
+			userID = "user_1"
+		}
+
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type step struct {
+		// Follow the previous page token rather than the next page token
+		previous    bool
+		expected    []string
+		hasNext     bool
+		hasPrevious bool
+	}
+
+	type testcase struct {
+		filters []Filter
+		reverse bool
+		orderBy *OrderBy
+		steps   []step
+	}
+
+	tcs := []testcase{
+		{
+			filters: []Filter{filters.Match("users", "user_0")},
+			steps: []step{
+				{expected: []string{"00000000", "00000001"}, hasNext: true},
+				{expected: []string{"00000003", "00000004"}, hasNext: true, hasPrevious: true},
+				{expected: []string{"00000005"}, hasPrevious: true},
+				{previous: true, expected: []string{"00000003", "00000004"}, hasNext: true, hasPrevious: true},
+				{previous: true, expected: []string{"00000000", "00000001"}, hasNext: true},
+				{expected: []string{"00000003", "00000004"}, hasNext: true, hasPrevious: true},
+			},
+		},
+		{
+			filters: []Filter{filters.Match("users", "user_0")},
+			reverse: true,
+			steps: []step{
+				{expected: []string{"00000005", "00000004"}, hasNext: true},
+				{expected: []string{"00000003", "00000001"}, hasNext: true, hasPrevious: true},
+				{expected: []string{"00000000"}, hasPrevious: true},
+				{previous: true, expected: []string{"00000003", "00000001"}, hasNext: true, hasPrevious: true},
+			},
+		},
+		{
+			steps: []step{
+				{expected: []string{"00000000", "00000001"}, hasNext: true},
+				{expected: []string{"00000002", "00000003"}, hasNext: true, hasPrevious: true},
+				{expected: []string{"00000004", "00000005"}, hasPrevious: true},
+				{previous: true, expected: []string{"00000002", "00000003"}, hasNext: true, hasPrevious: true},
+			},
+		},
+		{
+			orderBy: &OrderBy{RelationshipKey: "users", Descending: true},
+			steps: []step{
+				{expected: []string{"00000002", "00000005"}, hasNext: true},
+				{expected: []string{"00000004", "00000003"}, hasNext: true, hasPrevious: true},
+				{previous: true, expected: []string{"00000002", "00000005"}, hasNext: true},
+			},
+		},
+	}
+
+	for i, tc := range tcs {
+		var page Page
+		for j, s := range tc.steps {
+			o := NewFilteringOpts(tc.filters...)
+			o.Reverse = tc.reverse
+			o.OrderBy = tc.orderBy
+			o.Limit = 2
+			if o.PageToken = page.Next; s.previous {
+				o.PageToken = page.Previous
+			}
+
+			var entries []*testStruct
+			if page, err = m.GetPage(&entries, o); err != nil {
+				t.Fatalf("test case #%d, step #%d: %v", i, j, err)
+			}
+
+			if err = testCheckIDs(entries, s.expected); err != nil {
+				t.Fatalf("test case #%d, step #%d: %v", i, j, err)
+			}
+
+			if hasNext := page.Next != ""; hasNext != s.hasNext {
+				t.Fatalf("test case #%d, step #%d: invalid next token, expected presence of %v and received %v", i, j, s.hasNext, hasNext)
+			}
+
+			if hasPrevious := page.Previous != ""; hasPrevious != s.hasPrevious {
+				t.Fatalf("test case #%d, step #%d: invalid previous token, expected presence of %v and received %v", i, j, s.hasPrevious, hasPrevious)
+			}
+		}
+	}
+}
+
+func TestMojura_GetPage_invalid_token(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 3; i++ {
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o := NewFilteringOpts(filters.Match("users", "user_0"))
+	o.Limit = 1
+
+	var (
+		entries []*testStruct
+		page    Page
+	)
+
+	if page, err = m.GetPage(&entries, o); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tokens cannot be used with a different query
+	mismatched := NewFilteringOpts(filters.Match("users", "user_1"))
+	mismatched.PageToken = page.Next
+	if _, err = m.GetPage(&entries, mismatched); err != ErrPageTokenMismatch {
+		t.Fatalf("invalid error, expected %v and received %v", ErrPageTokenMismatch, err)
+	}
+
+	// Tokens cannot be used with different iterating options
+	nonDistinct := NewFilteringOpts(filters.Match("users", "user_0"))
+	nonDistinct.Distinct = false
+	nonDistinct.PageToken = page.Next
+	if _, err = m.GetPage(&entries, nonDistinct); err != ErrPageTokenMismatch {
+		t.Fatalf("invalid error, expected %v and received %v", ErrPageTokenMismatch, err)
+	}
+
+	// Tokens cannot be modified
+	tampered := []byte(page.Next)
+	if tampered[4] == 'A' {
+		tampered[4] = 'B'
+	} else {
+		tampered[4] = 'A'
+	}
+
+	o.PageToken = string(tampered)
+	if _, err = m.GetPage(&entries, o); err != ErrInvalidPageToken {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidPageToken, err)
+	}
+
+	o.PageToken = "not a token"
+	if _, err = m.GetPage(&entries, o); err != ErrInvalidPageToken {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidPageToken, err)
+	}
+}
+
+func TestMojura_GetPage_delimited_relationship_ID(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for _, tag := range []string{"org::2", "org::1", "org::3"} {
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "", tag)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o := NewFilteringOpts()
+	o.OrderBy = &OrderBy{RelationshipKey: "tags"}
+	o.Limit = 1
+
+	var ids []string
+	for {
+		var (
+			entries []*testStruct
+			page    Page
+		)
+
+		if page, err = m.GetPage(&entries, o); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+
+		if o.PageToken = page.Next; page.Next == "" {
+			break
+		}
+	}
+
+	expected := []string{"00000001", "00000000", "00000002"}
+	if len(ids) != len(expected) {
+		t.Fatalf("invalid IDs, expected %v and received %v", expected, ids)
+	}
+
+	for i, id := range ids {
+		if id != expected[i] {
+			t.Fatalf("invalid IDs, expected %v and received %v", expected, ids)
+		}
+	}
+}
+
+func TestMojura_GetPage_distinct(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	// Entries hold multiple tags within the range, so each entry is matched by multiple relationship IDs
+	for i := 0; i < 30; i++ {
+		tags := []string{fmt.Sprintf("t%d", i%5), fmt.Sprintf("t%d", (i*2)%5), fmt.Sprintf("t%d", (i+3)%5)}
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "", tags...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, reverse := range []bool{false, true} {
+		for _, limit := range []int64{1, 4, 7} {
+			testGetPageDistinct(t, m, reverse, limit)
+		}
+	}
+}
+
+func testGetPageDistinct(t *testing.T, m *Mojura, reverse bool, limit int64) {
+	o := NewFilteringOpts(filters.Range("tags", "t1", "t3"))
+	o.Reverse = reverse
+
+	var (
+		all []*testStruct
+		err error
+	)
+
+	if _, err = m.GetFiltered(&all, o); err != nil {
+		t.Fatal(err)
+	}
+
+	o.Limit = limit
+
+	// Follow the next page tokens until the last page
+	var pages [][]*testStruct
+	for {
+		var (
+			entries []*testStruct
+			page    Page
+		)
+
+		if page, err = m.GetPage(&entries, o); err != nil {
+			t.Fatal(err)
+		}
+
+		pages = append(pages, entries)
+		if page.Next == "" {
+			o.PageToken = page.Previous
+			break
+		}
+
+		o.PageToken = page.Next
+	}
+
+	var paged []*testStruct
+	for _, entries := range pages {
+		paged = append(paged, entries...)
+	}
+
+	expected := make([]string, len(all))
+	for i, entry := range all {
+		expected[i] = entry.ID
+	}
+
+	if err = testCheckIDs(paged, expected); err != nil {
+		t.Fatalf("reverse %v, limit %d: %v", reverse, limit, err)
+	}
+
+	// Follow the previous page tokens back to the first page
+	for i := len(pages) - 2; i >= 0; i-- {
+		var (
+			entries []*testStruct
+			page    Page
+		)
+
+		if page, err = m.GetPage(&entries, o); err != nil {
+			t.Fatal(err)
+		}
+
+		expected = expected[:0]
+		for _, entry := range pages[i] {
+			expected = append(expected, entry.ID)
+		}
+
+		if err = testCheckIDs(entries, expected); err != nil {
+			t.Fatalf("reverse %v, limit %d, page #%d: %v", reverse, limit, i, err)
+		}
+
+		o.PageToken = page.Previous
+	}
+
+	if o.PageToken != "" {
+		t.Fatalf("reverse %v, limit %d: invalid previous token, expected the first page to have no previous token", reverse, limit)
+	}
+}
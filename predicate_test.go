@@ -0,0 +1,243 @@
+package mojura
+
+import (
+	"testing"
+
+	"github.com/hatchify/errors"
+	"github.com/mojura/mojura/filters"
+)
+
+func TestMojura_GetFiltered_Predicate(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 8; i++ {
+		userID := "user_0"
+		if i%4 == 3 {
+			userID = "user_1"
+		}
+
+		value := "keep"
+		if i%2 == 1 {
+			value = "drop"
+		}
+
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	isKept := filters.PredicateOf(func(v *testStruct) (bool, error) {
+		return v.Value == "keep", nil
+	})
+
+	type testcase struct {
+		filters  []Filter
+		reverse  bool
+		limit    int64
+		expected [][]string
+	}
+
+	tcs := []testcase{
+		{
+			filters:  []Filter{isKept},
+			expected: [][]string{{"00000000", "00000002", "00000004", "00000006"}},
+		},
+		{
+			filters:  []Filter{isKept},
+			limit:    3,
+			expected: [][]string{{"00000000", "00000002", "00000004"}, {"00000006"}},
+		},
+		{
+			filters:  []Filter{isKept, filters.Match("users", "user_0")},
+			limit:    2,
+			expected: [][]string{{"00000000", "00000002"}, {"00000004", "00000006"}, {}},
+		},
+		{
+			filters:  []Filter{filters.Match("users", "user_0"), isKept},
+			reverse:  true,
+			limit:    3,
+			expected: [][]string{{"00000006", "00000004", "00000002"}, {"00000000"}},
+		},
+		{
+			filters:  []Filter{filters.Match("users", "user_1"), isKept},
+			expected: [][]string{{}},
+		},
+	}
+
+	for i, tc := range tcs {
+		o := NewFilteringOpts(tc.filters...)
+		o.Reverse = tc.reverse
+		if tc.limit > 0 {
+			o.Limit = tc.limit
+		}
+
+		for page, expected := range tc.expected {
+			var filtered []*testStruct
+			if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, page, err)
+			}
+
+			if err = testCheckIDs(filtered, expected); err != nil {
+				t.Fatalf("test case #%d, page #%d: %v", i, page, err)
+			}
+		}
+	}
+}
+
+func TestMojura_GetPage_Predicate(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 6; i++ {
+		value := "keep"
+		if i%3 == 1 {
+			value = "drop"
+		}
+
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o := NewFilteringOpts(filters.Match("users", "user_0"), filters.PredicateOf(func(v *testStruct) (bool, error) {
+		return v.Value == "keep", nil
+	}))
+	o.Limit = 3
+
+	var (
+		filtered []*testStruct
+		page     Page
+	)
+
+	// Predicates cannot be told apart without a page query ID
+	if _, err = m.GetPage(&filtered, o); err != ErrMissingPageQueryID {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMissingPageQueryID, err)
+	}
+
+	o.PageQueryID = "keep"
+	if page, err = m.GetPage(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckIDs(filtered, []string{"00000000", "00000002", "00000003"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page.Next) == 0 {
+		t.Fatal("expected next page token")
+	}
+
+	// Tokens cannot be used once only the predicate has changed
+	mismatched := NewFilteringOpts(filters.Match("users", "user_0"), filters.PredicateOf(func(v *testStruct) (bool, error) {
+		return v.Value == "drop", nil
+	}))
+	mismatched.PageQueryID = "drop"
+	mismatched.PageToken = page.Next
+	if _, err = m.GetPage(&filtered, mismatched); err != ErrPageTokenMismatch {
+		t.Fatalf("invalid error, expected %v and received %v", ErrPageTokenMismatch, err)
+	}
+
+	filtered = filtered[:0]
+	o.PageToken = page.Next
+	if page, err = m.GetPage(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckIDs(filtered, []string{"00000005"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page.Next) != 0 {
+		t.Fatalf("expected no next page token and received <%s>", page.Next)
+	}
+}
+
+func TestMojura_ForEach_Predicate_error(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	errPredicate := errors.Error("predicate error")
+	o := NewIteratingOpts(filters.Predicate(func(filters.Value) (bool, error) {
+		return false, errPredicate
+	}))
+
+	if err = m.ForEachID(func(entryID string) (err error) {
+		return
+	}, o); err != errPredicate {
+		t.Fatalf("invalid error, expected %v and received %v", errPredicate, err)
+	}
+}
+
+func TestMojura_Explain_Predicate(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 4; i++ {
+		value := "keep"
+		if i%2 == 1 {
+			value = "drop"
+		}
+
+		if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	predicate := filters.PredicateOf(func(v *testStruct) (bool, error) {
+		return v.Value == "keep", nil
+	})
+
+	var e *Explanation
+	if e, err = m.Explain(NewFilteringOpts(filters.Match("users", "user_0"), predicate)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(e.Cursors) != 1 {
+		t.Fatalf("invalid number of cursors, expected %d and received %d", 1, len(e.Cursors))
+	}
+
+	if len(e.PostFilters) != 1 || e.PostFilters[0] != predicate {
+		t.Fatalf("invalid post filters, expected %+v and received %+v", []Filter{predicate}, e.PostFilters)
+	}
+
+	if e.EntriesRejected != 2 {
+		t.Fatalf("invalid entries rejected, expected %d and received %d", 2, e.EntriesRejected)
+	}
+
+	if e.EntriesDecoded != 4 {
+		t.Fatalf("invalid entries decoded, expected %d and received %d", 4, e.EntriesDecoded)
+	}
+}
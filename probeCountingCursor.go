@@ -0,0 +1,21 @@
+package mojura
+
+var _ filterCursor = &probeCountingCursor{}
+
+// probeCountingCursor wraps a filter cursor to count the number of HasForward/HasReverse probes
+type probeCountingCursor struct {
+	filterCursor
+	counter *int64
+}
+
+// HasForward will determine if an entry exists in a forward direction
+func (c *probeCountingCursor) HasForward(entryID []byte) (ok bool, err error) {
+	*c.counter++
+	return c.filterCursor.HasForward(entryID)
+}
+
+// HasReverse will determine if an entry exists in a reverse direction
+func (c *probeCountingCursor) HasReverse(entryID []byte) (ok bool, err error) {
+	*c.counter++
+	return c.filterCursor.HasReverse(entryID)
+}
@@ -0,0 +1,375 @@
+package mojura
+
+import (
+	"bytes"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+// queryPlan represents the filters used to drive a filtered query
+type queryPlan struct {
+	// Primary filter which drives iteration
+	// Note: When nil, iteration is driven by scanning all entries
+	primary Filter
+	// Secondary filters which are probed for each entry of the primary
+	secondary []Filter
+	// Estimated number of keys scanned by the primary
+	// Note: Estimates are only set when the query planner has been ran
+	estimate int64
+	// Whether or not the primary yields each entry once
+	distinct bool
+	// Whether or not the query iterates in reverse, distinct entries are positioned at their first
+	// matching relationship ID in this direction (including when a page is iterated backward)
+	reverse bool
+	// Predicates which are applied to decoded entries after the primary and secondary filters match
+	predicates []*filters.PredicateFilter
+
+	// Index of the primary within the indexed filters
+	primaryIndex int
+	// Whether or not the primary was chosen by its estimated cost (or pinned to a previously chosen primary)
+	// Note: Estimates change as entries are written, so positions record the index of such a primary to
+	// resume iteration with the same primary (see getPin)
+	pinned bool
+}
+
+// getPin will return the index of the primary when it is pinned, -1 is returned otherwise
+func (p *queryPlan) getPin() (pin int) {
+	if !p.pinned {
+		return -1
+	}
+
+	return p.primaryIndex
+}
+
+// maxEstimateKeys is the maximum number of relationship counts read when estimating a range or prefix filter
+const maxEstimateKeys = 1024
+
+// filterEstimate represents the estimated cost of a filter
+type filterEstimate struct {
+	// Number of keys scanned when iterating the filter as a primary
+	scan int64
+	// Number of keys scanned for each HasForward/HasReverse probe as a secondary
+	probe int64
+}
+
+// isPrimaryCandidate will return whether or not a filter can drive iteration
+// Note: Inverse filters skip entries which have no relationships and may return entries
+// more than once when used as a primary, so they are always probed instead
+func isPrimaryCandidate(f Filter) (ok bool) {
+	switch f.(type) {
+	case *filters.InverseMatchFilter, *filters.NotInFilter:
+		return false
+
+	default:
+		return true
+	}
+}
+
+// getQueryPlan will return the query plan for the provided iterating options
+// Note: When the last ID records the index of its primary, the plan is pinned to that primary
+func (t *Transaction) getQueryPlan(o *IteratingOpts) (p queryPlan, err error) {
+	var pin int
+	if pin, err = getSeekIDPin(o.LastID); err != nil {
+		return
+	}
+
+	return t.getPinnedQueryPlan(o, pin)
+}
+
+// getPinnedQueryPlan will return the query plan for the provided iterating options. When the pin is not -1,
+// the indexed filter at the pinned index is used as the primary rather than the filter chosen by the planner
+func (t *Transaction) getPinnedQueryPlan(o *IteratingOpts, pin int) (p queryPlan, err error) {
+	fs, predicates := getPartedPredicates(o.Filters)
+	switch {
+	case o.OrderBy != nil:
+		// The order by relationship drives iteration, all filters are probed
+		p.primary = filters.Comparison(o.OrderBy.RelationshipKey, matchAllComparisonFn)
+		p.secondary = fs
+	case len(fs) == 0:
+		// No indexed filters are set, scan all entries
+	case pin != -1:
+		if pin >= len(fs) || !isPrimaryCandidate(fs[pin]) {
+			err = ErrInvalidLastID
+			return
+		}
+
+		p = newQueryPlanWithPrimary(fs, pin)
+		p.pinned = true
+	default:
+		if p, err = t.newQueryPlan(fs, o.PreserveFilterOrder); err != nil {
+			return
+		}
+	}
+
+	p.distinct = o.Distinct
+	p.reverse = o.applyOrder(o.Reverse)
+	p.predicates = predicates
+	return
+}
+
+// getPartedPredicates will separate the predicate filters from the indexed filters
+func getPartedPredicates(fs []Filter) (indexed []Filter, predicates []*filters.PredicateFilter) {
+	for _, f := range fs {
+		if predicate, ok := f.(*filters.PredicateFilter); ok {
+			predicates = append(predicates, predicate)
+			continue
+		}
+
+		indexed = append(indexed, f)
+	}
+
+	return
+}
+
+func (t *Transaction) newQueryPlan(fs []Filter, preserveOrder bool) (p queryPlan, err error) {
+	var candidates []int
+	for i, f := range fs {
+		if isPrimaryCandidate(f) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	switch {
+	case len(candidates) == 0:
+		// All filters are inverse filters, scan all entries and probe each filter
+		p.secondary = fs
+		p.estimate = t.getEntriesCount()
+		return
+	case preserveOrder || t.m.opts.DisableQueryPlanner:
+		// The first filter which can drive iteration is the primary, preceding inverse filters are probed
+		return newQueryPlanWithPrimary(fs, candidates[0]), nil
+	case len(candidates) == 1:
+		// Only one filter can drive iteration, no need to estimate
+		return newQueryPlanWithPrimary(fs, candidates[0]), nil
+	}
+
+	estimates := make([]filterEstimate, len(fs))
+	for i, f := range fs {
+		if estimates[i], err = t.estimateFilter(f); err != nil {
+			return
+		}
+	}
+
+	primaryIndex := -1
+	var lowestCost float64
+	for _, index := range candidates {
+		cost := getPlanCost(estimates, index)
+		// Ties are resolved by the original filter order
+		if primaryIndex == -1 || cost < lowestCost {
+			primaryIndex = index
+			lowestCost = cost
+		}
+	}
+
+	p = newQueryPlanWithPrimary(fs, primaryIndex)
+	p.estimate = estimates[primaryIndex].scan
+	p.pinned = true
+	return
+}
+
+func newQueryPlanWithPrimary(fs []Filter, primaryIndex int) (p queryPlan) {
+	p.primary = fs[primaryIndex]
+	p.primaryIndex = primaryIndex
+	p.secondary = make([]Filter, 0, len(fs)-1)
+	for i, f := range fs {
+		if i != primaryIndex {
+			p.secondary = append(p.secondary, f)
+		}
+	}
+
+	return
+}
+
+// getPlanCost will return the estimated number of keys scanned when the filter at the provided index is the primary
+func getPlanCost(estimates []filterEstimate, primaryIndex int) (cost float64) {
+	probes := float64(1)
+	for i, e := range estimates {
+		if i != primaryIndex {
+			probes += float64(e.probe)
+		}
+	}
+
+	return float64(estimates[primaryIndex].scan) * probes
+}
+
+func (t *Transaction) getEntriesCount() (count int64) {
+	bkt, err := t.getStatsBucket()
+	if err != nil {
+		return
+	}
+
+	return getCount(bkt, entriesStatKey)
+}
+
+func (t *Transaction) getMissingCount(relationshipKey string) (count int64, err error) {
+	var bkt backend.Bucket
+	if bkt, err = t.getStatsBucket(); err != nil {
+		return
+	}
+
+	count = getCount(bkt, getMissingStatKey([]byte(relationshipKey)))
+	return
+}
+
+func (t *Transaction) sumRelationshipCounts(relationshipKey string, relationshipIDs ...string) (sum int64, err error) {
+	var bkt backend.Bucket
+	if bkt, err = t.getCountsBucket([]byte(relationshipKey)); err != nil {
+		return
+	}
+
+	for _, relationshipID := range relationshipIDs {
+		if len(relationshipID) == 0 {
+			continue
+		}
+
+		sum += getCount(bkt, []byte(relationshipID))
+	}
+
+	return
+}
+
+func (t *Transaction) sumRelationshipRangeCounts(relationshipKey, rangeStart, rangeEnd string) (sum int64, err error) {
+	var bkt backend.Bucket
+	if bkt, err = t.getCountsBucket([]byte(relationshipKey)); err != nil {
+		return
+	}
+
+	end := []byte(rangeEnd)
+	cur := bkt.Cursor()
+	k, v := cur.First()
+	if len(rangeStart) > 0 {
+		k, v = cur.Seek([]byte(rangeStart))
+	}
+
+	sum = t.sumEstimateCounts(cur, k, v, func(k []byte) bool {
+		return len(end) == 0 || bytes.Compare(k, end) != 1
+	})
+
+	return
+}
+
+func (t *Transaction) sumRelationshipPrefixCounts(relationshipKey, prefix string) (sum int64, err error) {
+	var bkt backend.Bucket
+	if bkt, err = t.getCountsBucket([]byte(relationshipKey)); err != nil {
+		return
+	}
+
+	bs := []byte(prefix)
+	cur := bkt.Cursor()
+	k, v := cur.Seek(bs)
+	sum = t.sumEstimateCounts(cur, k, v, func(k []byte) bool {
+		return bytes.HasPrefix(k, bs)
+	})
+
+	return
+}
+
+// sumEstimateCounts will sum the counts from the current position of the cursor while the keys are within bounds
+// Note: Walks are limited to maxEstimateKeys keys, larger walks are estimated as a scan of all entries
+func (t *Transaction) sumEstimateCounts(cur backend.Cursor, k, v []byte, inBounds func(k []byte) bool) (sum int64) {
+	var keys int
+	for ; k != nil && inBounds(k); k, v = cur.Next() {
+		if keys++; keys > maxEstimateKeys {
+			if entries := t.getEntriesCount(); entries > sum {
+				return entries
+			}
+
+			return
+		}
+
+		sum += parseCount(v)
+	}
+
+	return
+}
+
+func (t *Transaction) estimateFilter(f Filter) (e filterEstimate, err error) {
+	switch n := t.m.normalizeFilter(f).(type) {
+	case *filters.MatchFilter:
+		e.scan, err = t.sumRelationshipCounts(n.RelationshipKey, n.RelationshipID)
+		e.probe = 1
+	case *filters.InFilter:
+		e.scan, err = t.sumRelationshipCounts(n.RelationshipKey, n.RelationshipIDs...)
+		e.probe = int64(len(n.RelationshipIDs))
+	case *filters.InverseMatchFilter:
+		var matches int64
+		matches, err = t.sumRelationshipCounts(n.RelationshipKey, n.RelationshipID)
+		e.scan = t.getEntriesCount() - matches
+		e.probe = 1
+	case *filters.NotInFilter:
+		var matches int64
+		matches, err = t.sumRelationshipCounts(n.RelationshipKey, n.RelationshipIDs...)
+		e.scan = t.getEntriesCount() - matches
+		e.probe = int64(len(n.RelationshipIDs))
+	case *filters.ComparisonFilter:
+		if len(n.RangeStart) == 0 && len(n.RangeEnd) == 0 {
+			// Unbounded comparisons are estimated as a scan of all entries rather than a walk of every relationship
+			e.scan = t.getEntriesCount()
+		} else {
+			e.scan, err = t.sumRelationshipRangeCounts(n.RelationshipKey, n.RangeStart, n.RangeEnd)
+		}
+
+		// Comparison probes iterate through the matching relationships
+		e.probe = e.scan
+	case *filters.PrefixFilter:
+		e.scan, err = t.sumRelationshipPrefixCounts(n.RelationshipKey, n.Prefix)
+		// Prefix probes iterate through the matching relationships
+		e.probe = e.scan
+	case *filters.HasRelationshipFilter:
+		// Has relationship cursors iterate through all entries
+		e.scan = t.getEntriesCount()
+		e.probe = 1
+	case *filters.MissingRelationshipFilter:
+		e.scan, err = t.getMissingCount(n.RelationshipKey)
+		e.probe = 1
+	case *filters.OrFilter:
+		for _, child := range n.Filters {
+			var childEstimate filterEstimate
+			if childEstimate, err = t.estimateFilter(child); err != nil {
+				return
+			}
+
+			e.scan += childEstimate.scan
+			e.probe += childEstimate.probe
+		}
+	case *filters.AndFilter:
+		e.scan = t.getEntriesCount()
+		for _, child := range n.Filters {
+			var childEstimate filterEstimate
+			if childEstimate, err = t.estimateFilter(child); err != nil {
+				return
+			}
+
+			// Conjunctions scan at most the keys of their smallest child
+			if isPrimaryCandidate(child) && childEstimate.scan < e.scan {
+				e.scan = childEstimate.scan
+			}
+
+			e.probe += childEstimate.probe
+		}
+	case *filters.TextFilter:
+		// Text cursors are conjunctions of their terms
+		return t.estimateFilter(getTextTermsFilter(n))
+	case *filters.NotFilter:
+		// Not cursors iterate through all entries and probe their child
+		e.scan = t.getEntriesCount()
+		var childEstimate filterEstimate
+		if childEstimate, err = t.estimateFilter(n.Filter); err != nil {
+			return
+		}
+
+		e.probe = childEstimate.probe
+
+	default:
+		// Unknown filters are estimated as a scan of all entries
+		e.scan = t.getEntriesCount()
+		e.probe = 1
+	}
+
+	if e.scan < 0 {
+		e.scan = 0
+	}
+
+	return
+}
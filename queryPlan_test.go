@@ -0,0 +1,505 @@
+package mojura
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+func Test_queryPlan_counts(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0", "tag_0", "tag_1"),
+		newTestStruct("user_0", "contact_1", "group_0", "1", "tag_0"),
+		newTestStruct("user_1", "", "group_0", "2"),
+	}
+
+	for _, entry := range entries {
+		if entry.ID, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = testCheckCounts(m, 3, map[string]int64{
+		"users.user_0": 2, "users.user_1": 1, "groups.group_0": 3,
+		"tags.tag_0": 2, "tags.tag_1": 1, "missing.contacts": 1, "missing.tags": 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries[0].UserID = "user_1"
+	entries[0].Tags = nil
+	if err = m.Edit(entries[0].ID, entries[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Put(entries[1].ID, entries[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Remove(entries[2].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckCounts(m, 2, map[string]int64{
+		"users.user_0": 1, "users.user_1": 1, "groups.group_0": 2,
+		"tags.tag_0": 1, "tags.tag_1": 0, "missing.contacts": 0, "missing.tags": 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the counts and stats to simulate a DB which was created before they existed
+	if err = m.db.Transaction(func(txn backend.Transaction) (err error) {
+		if err = txn.GetBucket(countsBktKey).DeleteBucket([]byte("users")); err != nil {
+			return
+		}
+
+		stats := txn.GetBucket(statsBktKey)
+		if err = stats.Delete(entriesStatKey); err != nil {
+			return
+		}
+
+		return stats.Delete(getMissingStatKey([]byte("tags")))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckCounts(m, 2, map[string]int64{
+		"users.user_0": 1, "users.user_1": 1, "missing.tags": 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_queryPlan_primary(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 20; i++ {
+		userID := fmt.Sprintf("user_%d", i%10)
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type testcase struct {
+		filters       []Filter
+		preserveOrder bool
+		// Index of the expected primary filter, -1 represents a scan of all entries
+		expected int
+	}
+
+	tcs := []testcase{
+		{
+			filters:  []Filter{filters.Match("groups", "group_0"), filters.Match("users", "user_1")},
+			expected: 1,
+		},
+		{
+			filters:       []Filter{filters.Match("groups", "group_0"), filters.Match("users", "user_1")},
+			preserveOrder: true,
+			expected:      0,
+		},
+		{
+			filters:  []Filter{filters.Match("users", "user_1"), filters.Match("users", "user_2")},
+			expected: 0,
+		},
+		{
+			// Comparison probes iterate through the matching relationships, so a broad comparison
+			// is cheaper to iterate than to probe
+			filters:  []Filter{filters.GreaterThan("users", "user_0"), filters.In("users", "user_1", "user_2")},
+			expected: 0,
+		},
+		{
+			filters:  []Filter{filters.Match("groups", "group_0"), filters.GreaterThan("users", "user_8")},
+			expected: 1,
+		},
+		{
+			filters:  []Filter{filters.InverseMatch("users", "user_1"), filters.Match("contacts", "contact_0")},
+			expected: 1,
+		},
+		{
+			filters:  []Filter{filters.InverseMatch("users", "user_1"), filters.NotIn("contacts", "contact_1")},
+			expected: -1,
+		},
+		{
+			// Inverse filters are probed even when the filter order is preserved
+			filters:       []Filter{filters.NotIn("users", "user_1"), filters.Match("groups", "group_0")},
+			preserveOrder: true,
+			expected:      1,
+		},
+		{
+			filters:       []Filter{filters.InverseMatch("users", "user_1"), filters.NotIn("contacts", "contact_1")},
+			preserveOrder: true,
+			expected:      -1,
+		},
+		{
+			filters:  []Filter{filters.Match("users", "user_404"), filters.Match("groups", "group_0")},
+			expected: 0,
+		},
+	}
+
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		for i, tc := range tcs {
+			var p queryPlan
+			if p, err = txn.newQueryPlan(tc.filters, tc.preserveOrder); err != nil {
+				return
+			}
+
+			var expected Filter
+			if tc.expected > -1 {
+				expected = tc.filters[tc.expected]
+			}
+
+			if p.primary != expected {
+				return fmt.Errorf("test case #%d: invalid primary, expected %v and received %v", i, expected, p.primary)
+			}
+
+			if len(p.secondary) != len(tc.filters)-1 && expected != nil {
+				return fmt.Errorf("test case #%d: invalid number of secondary filters, received %d", i, len(p.secondary))
+			}
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_queryPlan_DisableQueryPlanner(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.DisableQueryPlanner = true
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := []Filter{filters.Match("groups", "group_0"), filters.Match("users", "user_404")}
+	inverseFirst := []Filter{filters.InverseMatch("users", "user_404"), filters.Match("groups", "group_0")}
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		var p queryPlan
+		if p, err = txn.newQueryPlan(fs, false); err != nil {
+			return
+		}
+
+		if p.primary != fs[0] {
+			return fmt.Errorf("invalid primary, expected %v and received %v", fs[0], p.primary)
+		}
+
+		if p, err = txn.newQueryPlan(inverseFirst, false); err != nil {
+			return
+		}
+
+		if p.primary != inverseFirst[1] {
+			return fmt.Errorf("invalid primary, expected %v and received %v", inverseFirst[1], p.primary)
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_queryPlan_inverse_only(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "0"),
+		newTestStruct("user_1", "contact_1", "group_0", "1"),
+		newTestStruct("", "contact_0", "group_1", "2"),
+		newTestStruct("user_2", "contact_1", "group_1", "3"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.InverseMatch("users", "user_0")},
+			expected: []string{"00000001", "00000002", "00000003"},
+		},
+		{
+			filters:  []Filter{filters.InverseMatch("users", "user_0"), filters.NotIn("groups", "group_1")},
+			reverse:  true,
+			expected: []string{"00000001"},
+		},
+	})
+
+	// Paginate through an inverse only query
+	o := NewFilteringOpts(filters.InverseMatch("users", "user_1"))
+	o.Limit = 2
+
+	var filtered []*testStruct
+	if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckIDs(filtered, []string{"00000000", "00000002", "00000003"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_queryPlan_pinned(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_0", "group_0", "", "t2"),
+		newTestStruct("user_0", "contact_0", "group_0", "", "t1"),
+		newTestStruct("user_0", "contact_0", "group_0", "", "t1"),
+		newTestStruct("user_9", "contact_0", "group_0", "", "t1"),
+		newTestStruct("user_9", "contact_0", "group_0", "", "t1"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o := NewFilteringOpts(filters.Range("users", "user_0", "user_1"), filters.Range("tags", "t1", "t3"))
+	o.Limit = 1
+
+	var (
+		filtered []*testStruct
+		page     Page
+	)
+
+	if o.LastID, err = m.GetFiltered(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if page, err = m.GetPage(&filtered, o); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries are added so the planner would choose the tags filter as the primary
+	for i := 0; i < 10; i++ {
+		if _, err = m.New(newTestStruct("user_1", "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The following pages are iterated with the users filter as the primary
+	for o.LastID != "" {
+		if o.LastID, err = m.GetFiltered(&filtered, o); err != nil && err != ErrEntryNotFound {
+			t.Fatal(err)
+		}
+	}
+
+	for page.Next != "" {
+		o.PageToken = page.Next
+		if page, err = m.GetPage(&filtered, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{"00000000", "00000000", "00000001", "00000002", "00000001", "00000002"}
+	if err = testCheckIDs(filtered, expected); err != nil {
+		t.Fatal(err)
+	}
+
+	// Last IDs cannot pin a primary which does not exist
+	o.PageToken = ""
+	o.LastID = joinPinnedSeekID(joinSeekID("user_0", "00000000"), 2)
+	if _, err = m.GetFiltered(&filtered, o); err != ErrInvalidLastID {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidLastID, err)
+	}
+}
+
+func Test_queryPlan_estimate(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	for i := 0; i < 20; i++ {
+		userID := fmt.Sprintf("user_%d", i%10)
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type testcase struct {
+		filter   Filter
+		expected int64
+	}
+
+	tcs := []testcase{
+		{filter: filters.GreaterThanOrEqualTo("users", "user_8"), expected: 4},
+		{filter: filters.Range("users", "user_2", "user_4"), expected: 6},
+		{filter: filters.Prefix("users", "user_1"), expected: 2},
+		{filter: filters.Prefix("users", "user_404"), expected: 0},
+		{
+			// Unbounded comparisons are estimated as a scan of all entries
+			filter:   filters.Comparison("users", func(string) (bool, error) { return true, nil }),
+			expected: 20,
+		},
+	}
+
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		for i, tc := range tcs {
+			var e filterEstimate
+			if e, err = txn.estimateFilter(tc.filter); err != nil {
+				return
+			}
+
+			if e.scan != tc.expected {
+				return fmt.Errorf("test case #%d: invalid scan estimate, expected %d and received %d", i, tc.expected, e.scan)
+			}
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_queryPlan_Cursor_preserves_order(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	entries := []*testStruct{
+		newTestStruct("user_0", "contact_2", "group_0", "0"),
+		newTestStruct("user_0", "contact_1", "group_0", "1"),
+		newTestStruct("user_1", "contact_0", "group_0", "2"),
+		newTestStruct("user_1", "contact_3", "group_0", "3"),
+	}
+
+	for _, entry := range entries {
+		if _, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The query planner would choose the match as the primary, cursors iterate in the order of the first filter
+	fs := []Filter{filters.GreaterThanOrEqualTo("contacts", "contact_0"), filters.Match("users", "user_0")}
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		var c IDCursor
+		if c, err = txn.IDCursor(fs...); err != nil {
+			return
+		}
+
+		var entryIDs []string
+		for entryID, err := c.First(); err == nil; entryID, err = c.Next() {
+			entryIDs = append(entryIDs, entryID)
+		}
+
+		expected := []string{"00000001", "00000000"}
+		if fmt.Sprint(entryIDs) != fmt.Sprint(expected) {
+			return fmt.Errorf("invalid entry IDs, expected %v and received %v", expected, entryIDs)
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testCheckCounts(m *Mojura, entries int64, expected map[string]int64) (err error) {
+	return m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		if count := txn.getEntriesCount(); count != entries {
+			return fmt.Errorf("invalid entries count, expected %d and received %d", entries, count)
+		}
+
+		for key, count := range expected {
+			relationshipKey, relationshipID := splitCountKey(key)
+
+			var received int64
+			if relationshipKey == "missing" {
+				received, err = txn.getMissingCount(relationshipID)
+			} else {
+				received, err = txn.sumRelationshipCounts(relationshipKey, relationshipID)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if received != count {
+				return fmt.Errorf("invalid count for <%s>, expected %d and received %d", key, count, received)
+			}
+		}
+
+		return
+	})
+}
+
+func splitCountKey(key string) (relationshipKey, relationshipID string) {
+	for i := range key {
+		if key[i] == '.' {
+			return key[:i], key[i+1:]
+		}
+	}
+
+	return key, ""
+}
@@ -0,0 +1,71 @@
+package mojura
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// newRankedEntries will return ranked entries which hold up to the provided limit of entries
+// Note: When limit is less than 1, all entries are held
+func newRankedEntries(limit int64, reverse bool) *rankedEntries {
+	var r rankedEntries
+	r.limit = limit
+	r.reverse = reverse
+	return &r
+}
+
+// rankedEntries holds the highest ranked entries, the lowest ranked entry is kept at the root of the heap
+type rankedEntries struct {
+	entries []rankedEntry
+	limit   int64
+	reverse bool
+}
+
+// Len will return the number of entries, used by heap.Interface
+func (r *rankedEntries) Len() int {
+	return len(r.entries)
+}
+
+// Less will return whether or not the entry at i is ranked after the entry at j, used by heap.Interface
+func (r *rankedEntries) Less(i, j int) bool {
+	return r.entries[j].isBefore(r.entries[i], r.reverse)
+}
+
+// Swap will swap the entries at i and j, used by heap.Interface
+func (r *rankedEntries) Swap(i, j int) {
+	r.entries[i], r.entries[j] = r.entries[j], r.entries[i]
+}
+
+// Push will append an entry, used by heap.Interface
+func (r *rankedEntries) Push(x interface{}) {
+	r.entries = append(r.entries, x.(rankedEntry))
+}
+
+// Pop will remove the last entry, used by heap.Interface
+func (r *rankedEntries) Pop() interface{} {
+	last := r.entries[len(r.entries)-1]
+	r.entries = r.entries[:len(r.entries)-1]
+	return last
+}
+
+// add will add an entry, the lowest ranked entry is dropped when the limit has been reached
+func (r *rankedEntries) add(entry rankedEntry) {
+	switch {
+	case r.limit < 1:
+		r.entries = append(r.entries, entry)
+	case int64(len(r.entries)) < r.limit:
+		heap.Push(r, entry)
+	case entry.isBefore(r.entries[0], r.reverse):
+		r.entries[0] = entry
+		heap.Fix(r, 0)
+	}
+}
+
+// sorted will return the entries in ranked order
+func (r *rankedEntries) sorted() (entries []rankedEntry) {
+	sort.Slice(r.entries, func(i, j int) bool {
+		return r.entries[i].isBefore(r.entries[j], r.reverse)
+	})
+
+	return r.entries
+}
@@ -0,0 +1,44 @@
+package mojura
+
+import "testing"
+
+func Test_rankedEntries(t *testing.T) {
+	entries := []rankedEntry{
+		{entryID: "00000000", score: 1},
+		{entryID: "00000001", score: 3},
+		{entryID: "00000002", score: 2},
+		{entryID: "00000003", score: 3},
+		{entryID: "00000004", score: 0},
+	}
+
+	type testcase struct {
+		limit    int64
+		reverse  bool
+		expected []string
+	}
+
+	tcs := []testcase{
+		{limit: 2, expected: []string{"00000001", "00000003"}},
+		{limit: 3, reverse: true, expected: []string{"00000004", "00000000", "00000002"}},
+		{limit: -1, expected: []string{"00000001", "00000003", "00000002", "00000000", "00000004"}},
+		{limit: 10, expected: []string{"00000001", "00000003", "00000002", "00000000", "00000004"}},
+	}
+
+	for i, tc := range tcs {
+		r := newRankedEntries(tc.limit, tc.reverse)
+		for _, entry := range entries {
+			r.add(entry)
+		}
+
+		sorted := r.sorted()
+		if len(sorted) != len(tc.expected) {
+			t.Fatalf("test case #%d: invalid number of entries, expected %d and received %d", i, len(tc.expected), len(sorted))
+		}
+
+		for j, entry := range sorted {
+			if entry.entryID != tc.expected[j] {
+				t.Fatalf("test case #%d: invalid entry ID at index %d, expected %s and received %s", i, j, tc.expected[j], entry.entryID)
+			}
+		}
+	}
+}
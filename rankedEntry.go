@@ -0,0 +1,22 @@
+package mojura
+
+// rankedEntry represents an entry and its text ranking score
+type rankedEntry struct {
+	entryID string
+	score   int64
+}
+
+// isBefore will return whether or not the entry is ranked before the provided entry
+// Note: Entries are ranked by descending score and then ascending entry ID, reverse inverts the ranking
+func (r rankedEntry) isBefore(entry rankedEntry, reverse bool) (ok bool) {
+	if reverse {
+		r, entry = entry, r
+	}
+
+	if r.score == entry.score {
+		// Ties are ranked by entry ID
+		return r.entryID < entry.entryID
+	}
+
+	return r.score > entry.score
+}
@@ -0,0 +1,65 @@
+package mojura
+
+import "github.com/hatchify/errors"
+
+const (
+	// ReferenceRestrict will prevent a referenced entry from being removed while dependent entries exist
+	ReferenceRestrict ReferencePolicy = iota
+	// ReferenceCascade will remove dependent entries when a referenced entry is removed
+	ReferenceCascade
+	// ReferenceUnset will unset the relationship ID of dependent entries when a referenced entry is removed
+	// Note: Entries must implement ReferenceUnsetter to use this policy
+	ReferenceUnset
+)
+
+const (
+	// ErrInvalidReferencePolicy is returned when an unsupported reference policy is provided
+	ErrInvalidReferencePolicy = errors.Error("invalid reference policy")
+	// ErrInvalidReferenceTarget is returned when a reference target does not share a backend with the referencing collection
+	ErrInvalidReferenceTarget = errors.Error("invalid reference target, collections must share a DB")
+	// ErrReferenceExists is returned when a reference has already been set for a relationship key
+	ErrReferenceExists = errors.Error("reference already exists for relationship")
+	// ErrReferenceUnsetNotSupported is returned when the ReferenceUnset policy is used with an entry type which does not implement ReferenceUnsetter
+	ErrReferenceUnsetNotSupported = errors.Error("invalid reference policy, entry type does not implement ReferenceUnsetter")
+	// ErrReferencedEntryNotFound is returned when a relationship ID references an entry which does not exist
+	ErrReferencedEntryNotFound = errors.Error("referenced entry was not found")
+	// ErrReferenceRestricted is returned when removing an entry which is referenced by dependent entries
+	ErrReferenceRestricted = errors.Error("cannot remove entry, entry is referenced by dependent entries")
+)
+
+// ReferencePolicy represents the action taken on dependent entries when a referenced entry is removed
+type ReferencePolicy uint8
+
+// Validate will validate a reference policy
+func (r ReferencePolicy) Validate() (err error) {
+	switch r {
+	case ReferenceRestrict:
+	case ReferenceCascade:
+	case ReferenceUnset:
+
+	default:
+		return ErrInvalidReferencePolicy
+	}
+
+	return
+}
+
+// ReferenceUnsetter is implemented by entries which support the ReferenceUnset policy
+type ReferenceUnsetter interface {
+	// UnsetReference is called to remove a relationship ID from the provided relationship key
+	UnsetReference(relationshipKey, relationshipID string)
+}
+
+// reference represents a relationship key whose relationship IDs are entry IDs of the target
+type reference struct {
+	// Index of the relationship within the referencing collection
+	index  int
+	target *Mojura
+}
+
+// dependent represents a relationship key of a source collection which references entries
+type dependent struct {
+	source          *Mojura
+	relationshipKey []byte
+	policy          ReferencePolicy
+}
@@ -1,5 +1,7 @@
 package mojura
 
+import "github.com/mojura/mojura/filters"
+
 // Note: This is to support the deprecated GetRelationshipIDs method.
 // This will be removed in v0.6.0
 func newRelationshipsFromIDs(relationshipIDs []string) (r Relationships) {
@@ -10,6 +12,21 @@ func newRelationshipsFromIDs(relationshipIDs []string) (r Relationships) {
 	return
 }
 
+// isMissingRelationship will return whether or not the relationship at the provided index has no relationship IDs
+func isMissingRelationship(r Relationships, index int) (missing bool) {
+	if index >= len(r) {
+		return true
+	}
+
+	for _, relationshipID := range r[index] {
+		if len(relationshipID) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Relationships help to store the relationships for an Entry
 type Relationships []Relationship
 
@@ -18,6 +35,18 @@ func (r *Relationships) Append(relationshipIDs ...string) {
 	*r = append(*r, relationshipIDs)
 }
 
+// AppendTyped will append a set of typed relationship IDs for a given relationship
+// Note: Values are stored with an order-preserving encoding so they can be compared with
+// the typed filters (e.g. filters.GreaterThanTyped)
+func AppendTyped[T filters.Typed](r *Relationships, values ...T) {
+	relationshipIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		relationshipIDs = append(relationshipIDs, filters.Encode(value))
+	}
+
+	r.Append(relationshipIDs...)
+}
+
 // Relationship help to store the IDs for an Entry's relationship
 type Relationship []string
 
@@ -0,0 +1,125 @@
+package mojura
+
+import (
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+// withScanCounter will return a transaction whose cursors increment the counter for each key scanned
+func (t *Transaction) withScanCounter(counter *int64) (out *Transaction) {
+	clone := *t
+	clone.root = &countingRoot{root: t.root, counter: counter}
+	return &clone
+}
+
+func newCountingBucket(bkt backend.Bucket, counter *int64) backend.Bucket {
+	if bkt == nil {
+		// Ensure nil buckets are returned as nil interfaces
+		return nil
+	}
+
+	return &countingBucket{Bucket: bkt, counter: counter}
+}
+
+// countingRoot wraps a root to count the keys scanned by cursors of its buckets
+type countingRoot struct {
+	root    backend.Transaction
+	counter *int64
+}
+
+// GetBucket will return a counting bucket
+func (r *countingRoot) GetBucket(key []byte) (bkt backend.Bucket) {
+	return newCountingBucket(r.root.GetBucket(key), r.counter)
+}
+
+// GetOrCreateBucket will return a counting bucket
+func (r *countingRoot) GetOrCreateBucket(key []byte) (bkt backend.Bucket, err error) {
+	if bkt, err = r.root.GetOrCreateBucket(key); err != nil {
+		return
+	}
+
+	bkt = newCountingBucket(bkt, r.counter)
+	return
+}
+
+// countingBucket wraps a bucket to count the keys scanned by its cursors
+type countingBucket struct {
+	backend.Bucket
+	counter *int64
+}
+
+// GetBucket will return a counting bucket
+func (b *countingBucket) GetBucket(key []byte) (bkt backend.Bucket) {
+	return newCountingBucket(b.Bucket.GetBucket(key), b.counter)
+}
+
+// GetOrCreateBucket will return a counting bucket
+func (b *countingBucket) GetOrCreateBucket(key []byte) (bkt backend.Bucket, err error) {
+	if bkt, err = b.Bucket.GetOrCreateBucket(key); err != nil {
+		return
+	}
+
+	bkt = newCountingBucket(bkt, b.counter)
+	return
+}
+
+// Cursor will return a counting cursor
+func (b *countingBucket) Cursor() backend.Cursor {
+	return &countingCursor{Cursor: b.Bucket.Cursor(), counter: b.counter}
+}
+
+// countingCursor wraps a cursor to count the keys it scans
+type countingCursor struct {
+	backend.Cursor
+	counter *int64
+}
+
+func (c *countingCursor) count(key, value []byte) ([]byte, []byte) {
+	if key != nil {
+		*c.counter++
+	}
+
+	return key, value
+}
+
+// Seek will seek the provided key
+func (c *countingCursor) Seek(seekTo []byte) (key, value []byte) {
+	return c.count(c.Cursor.Seek(seekTo))
+}
+
+// First will return the first key
+func (c *countingCursor) First() (key, value []byte) {
+	return c.count(c.Cursor.First())
+}
+
+// Next will return the next key
+func (c *countingCursor) Next() (key, value []byte) {
+	return c.count(c.Cursor.Next())
+}
+
+// Prev will return the previous key
+func (c *countingCursor) Prev() (key, value []byte) {
+	return c.count(c.Cursor.Prev())
+}
+
+// Last will return the last key
+func (c *countingCursor) Last() (key, value []byte) {
+	return c.count(c.Cursor.Last())
+}
+
+// newRejectionCountingPredicate will combine the predicates and count the entries they reject
+func newRejectionCountingPredicate(predicates []filters.PredicateFn, counter *int64) filters.PredicateFn {
+	return func(val filters.Value) (ok bool, err error) {
+		for _, predicate := range predicates {
+			if ok, err = predicate(val); !ok || err != nil {
+				break
+			}
+		}
+
+		if !ok && err == nil {
+			*counter++
+		}
+
+		return
+	}
+}
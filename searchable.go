@@ -0,0 +1,8 @@
+package mojura
+
+// Searchable is an optional interface for values whose fields are indexed for text search
+// Note: Requires the text index to be enabled (see Opts.IndexText)
+type Searchable interface {
+	// GetSearchFields will return the text of the fields to index
+	GetSearchFields() []string
+}
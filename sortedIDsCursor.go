@@ -0,0 +1,175 @@
+package mojura
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/mojura/backend"
+)
+
+var _ filterCursor = &sortedIDsCursor{}
+
+// newSortedIDsCursor will collect the entry IDs of the provided filter cursor and iterate through them
+// in entry ID order. This is used to merge cursors which do not iterate in entry ID order (such as
+// comparison cursors, which iterate by relationship ID and then entry ID)
+func newSortedIDsCursor(txn *Transaction, fc filterCursor) (cur *sortedIDsCursor, err error) {
+	var c sortedIDsCursor
+	c.txn = txn
+	c.index = -1
+
+	var entryID []byte
+	for entryID, err = fc.First(); err == nil; entryID, err = fc.Next() {
+		c.ids = append(c.ids, entryID)
+	}
+
+	if err != Break {
+		return
+	}
+
+	sort.Slice(c.ids, func(i, j int) bool {
+		return bytes.Compare(c.ids[i], c.ids[j]) == -1
+	})
+
+	c.ids = dedupeSortedIDs(c.ids)
+	cur = &c
+	err = nil
+	return
+}
+
+// newInverseSortedIDsCursor will collect the entry IDs which match the provided inverse cursor in entry ID order
+// Note: The inverse cursor iterates the non-target buckets, which skips entries without relationship IDs and
+// yields entries which hold a target relationship ID alongside another relationship ID. Instead, all entries
+// are iterated and probed against the target buckets
+func newInverseSortedIDsCursor(txn *Transaction, ic *inverseMatchCursor) (cur *sortedIDsCursor, err error) {
+	var entriesBkt backend.Bucket
+	if entriesBkt, err = txn.getEntriesBucket(); err != nil {
+		return
+	}
+
+	var c sortedIDsCursor
+	c.txn = txn
+	c.index = -1
+
+	entriesCur := entriesBkt.Cursor()
+	for entryID, _ := entriesCur.First(); entryID != nil; entryID, _ = entriesCur.Next() {
+		var ok bool
+		if ok, err = ic.has(entryID); err != nil {
+			return
+		}
+
+		if ok {
+			c.ids = append(c.ids, entryID)
+		}
+	}
+
+	cur = &c
+	return
+}
+
+type sortedIDsCursor struct {
+	txn *Transaction
+
+	ids   [][]byte
+	index int
+}
+
+func (c *sortedIDsCursor) search(entryID []byte) (index int) {
+	return sort.Search(len(c.ids), func(i int) bool {
+		return bytes.Compare(c.ids[i], entryID) != -1
+	})
+}
+
+func (c *sortedIDsCursor) has(entryID []byte) (ok bool, err error) {
+	index := c.search(entryID)
+	ok = index < len(c.ids) && bytes.Equal(c.ids[index], entryID)
+	return
+}
+
+func (c *sortedIDsCursor) setIndex(index int) (entryID []byte, err error) {
+	switch {
+	case index < 0:
+		c.index = -1
+		err = Break
+	case index >= len(c.ids):
+		c.index = len(c.ids)
+		err = Break
+
+	default:
+		c.index = index
+		entryID = c.ids[index]
+	}
+
+	return
+}
+
+func (c *sortedIDsCursor) getCurrentRelationshipID() (relationshipID string) {
+	return ""
+}
+
+// SeekForward will seek the provided ID
+func (c *sortedIDsCursor) SeekForward(relationshipID, seekID []byte) (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.setIndex(c.search(seekID))
+}
+
+// SeekReverse will seek the provided ID
+func (c *sortedIDsCursor) SeekReverse(relationshipID, seekID []byte) (entryID []byte, err error) {
+	return c.SeekForward(relationshipID, seekID)
+}
+
+// First will return the first entry
+func (c *sortedIDsCursor) First() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.setIndex(0)
+}
+
+// Last will return the last entry
+func (c *sortedIDsCursor) Last() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.setIndex(len(c.ids) - 1)
+}
+
+// Next will return the next entry
+func (c *sortedIDsCursor) Next() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.setIndex(c.index + 1)
+}
+
+// Prev will return the previous entry
+func (c *sortedIDsCursor) Prev() (entryID []byte, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.setIndex(c.index - 1)
+}
+
+// HasForward will determine if an entry exists in a forward direction
+func (c *sortedIDsCursor) HasForward(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.has(entryID)
+}
+
+// HasReverse will determine if an entry exists in a reverse direction
+func (c *sortedIDsCursor) HasReverse(entryID []byte) (ok bool, err error) {
+	if err = c.txn.cc.isDone(); err != nil {
+		return
+	}
+
+	return c.has(entryID)
+}
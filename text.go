@@ -0,0 +1,355 @@
+package mojura
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+// maxTermLength is the maximum length of an indexed term, longer tokens are not indexed
+const maxTermLength = 64
+
+var textRelationshipKey = []byte(filters.TextKey)
+
+func isTextRelationship(relationship []byte) (ok bool) {
+	return string(relationship) == filters.TextKey
+}
+
+// getTerms will tokenize, lowercase and stem the provided text
+func getTerms(text string) (terms []string) {
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, token := range tokens {
+		if len(token) > maxTermLength {
+			continue
+		}
+
+		terms = append(terms, stem(token))
+	}
+
+	return
+}
+
+// getQueryTerms will return the unique terms of a text query
+func getQueryTerms(query string) (terms []string) {
+	seen := make(map[string]struct{})
+	for _, term := range getTerms(query) {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+
+		seen[term] = struct{}{}
+		terms = append(terms, term)
+	}
+
+	return
+}
+
+// getTermFrequencies will return the number of occurrences of each term within the searchable fields of a value
+// Note: Nil is returned for values which are not searchable
+func getTermFrequencies(val Value) (frequencies map[string]int64) {
+	searchable, ok := val.(Searchable)
+	if !ok {
+		return
+	}
+
+	frequencies = make(map[string]int64)
+	for _, field := range searchable.GetSearchFields() {
+		for _, term := range getTerms(field) {
+			frequencies[term]++
+		}
+	}
+
+	return
+}
+
+// stem will reduce an english word to its stem by removing common suffixes
+// Note: This is a light stemmer, it handles plurals and the -ed and -ing suffixes
+func stem(word string) (stemmed string) {
+	if len(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ies"):
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+	case strings.HasSuffix(word, "s"):
+		word = word[:len(word)-1]
+	}
+
+	for _, suffix := range []string{"ing", "ed"} {
+		base := strings.TrimSuffix(word, suffix)
+		if base == word || len(base) < 3 || !hasVowel(base) {
+			continue
+		}
+
+		if isDoubleConsonant(base) {
+			// Remove the doubled consonant (e.g. running -> run)
+			base = base[:len(base)-1]
+		}
+
+		return base
+	}
+
+	return word
+}
+
+func hasVowel(word string) (ok bool) {
+	return strings.ContainsAny(word, "aeiouy")
+}
+
+func isDoubleConsonant(word string) (ok bool) {
+	if len(word) < 2 {
+		return false
+	}
+
+	last := word[len(word)-1]
+	switch last {
+	case 'a', 'e', 'i', 'o', 'u', 'l', 's', 'z':
+		return false
+	}
+
+	return word[len(word)-2] == last
+}
+
+// getTextTermsFilter will return a filter which matches entries containing every term of a text filter
+func getTextTermsFilter(f *filters.TextFilter) (and *filters.AndFilter) {
+	terms := getQueryTerms(f.Query)
+	fs := make([]Filter, 0, len(terms))
+	for _, term := range terms {
+		fs = append(fs, filters.Match(filters.TextKey, term))
+	}
+
+	return filters.And(fs...)
+}
+
+func newTextCursor(txn *Transaction, f *filters.TextFilter) (c filterCursor, err error) {
+	// Ensure the text index exists, regardless of the number of terms
+	if _, err = txn.getRelationshipBucket(textRelationshipKey); err != nil {
+		return
+	}
+
+	and := getTextTermsFilter(f)
+	if len(and.Filters) == 0 {
+		// Queries without terms cannot match any entries
+		c = nopC
+		return
+	}
+
+	return newAndCursor(txn, and)
+}
+
+// initTextBucket will create (and backfill) the text index when enabled, and remove it when disabled
+func (m *Mojura) initTextBucket(root backend.Transaction, relationshipsBkt, countsBkt backend.Bucket) (err error) {
+	exists := relationshipsBkt.GetBucket(textRelationshipKey) != nil
+	switch {
+	case m.opts.IndexText && exists:
+		// Index already exists
+		return
+	case !m.opts.IndexText:
+		if err = deleteBucketIfExists(relationshipsBkt, textRelationshipKey); err != nil {
+			return
+		}
+
+		return deleteBucketIfExists(countsBkt, textRelationshipKey)
+	}
+
+	// Remove any existing (and potentially stale) counts
+	if err = deleteBucketIfExists(countsBkt, textRelationshipKey); err != nil {
+		return
+	}
+
+	var textBkt backend.Bucket
+	if textBkt, err = relationshipsBkt.GetOrCreateBucket(textRelationshipKey); err != nil {
+		return
+	}
+
+	// Index entries which existed before the text index was created
+	if err = root.GetBucket(entriesBktKey).ForEach(func(entryID, bs []byte) (err error) {
+		var val Value
+		if val, err = m.newValueFromBytes(bs); err != nil {
+			return
+		}
+
+		for term, frequency := range getTermFrequencies(val) {
+			var termBkt backend.Bucket
+			if termBkt, err = textBkt.GetOrCreateBucket([]byte(term)); err != nil {
+				return
+			}
+
+			if err = putCount(termBkt, entryID, frequency); err != nil {
+				return
+			}
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	return initCountsBucket(countsBkt, textBkt, textRelationshipKey)
+}
+
+// setText will update the text index for an entry
+// Note: orig is nil when the entry did not previously exist
+func (t *Transaction) setText(entryID []byte, orig, val Value) (err error) {
+	if !t.m.opts.IndexText {
+		return
+	}
+
+	if orig != nil {
+		if err = t.unsetText(entryID, orig); err != nil {
+			return
+		}
+	}
+
+	for term, frequency := range getTermFrequencies(val) {
+		if err = t.setRelationship(textRelationshipKey, []byte(term), entryID); err != nil {
+			return
+		}
+
+		var termBkt backend.Bucket
+		if termBkt, err = t.getTermBucket(term); err != nil {
+			return
+		}
+
+		// Term frequencies are stored as the value of the entry ID for ranking
+		if err = putCount(termBkt, entryID, frequency); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// unsetText will remove an entry from the text index
+func (t *Transaction) unsetText(entryID []byte, val Value) (err error) {
+	if !t.m.opts.IndexText {
+		return
+	}
+
+	for term := range getTermFrequencies(val) {
+		if err = t.unsetRelationship(textRelationshipKey, []byte(term), entryID); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (t *Transaction) getTermBucket(term string) (bkt backend.Bucket, err error) {
+	var textBkt backend.Bucket
+	if textBkt, err = t.getRelationshipBucket(textRelationshipKey); err != nil {
+		return
+	}
+
+	bkt = textBkt.GetBucket([]byte(term))
+	return
+}
+
+// getRankingTerms will return the unique terms of the top level text filters
+func getRankingTerms(fs []Filter) (terms []string) {
+	var queries []string
+	for _, f := range fs {
+		if text, ok := f.(*filters.TextFilter); ok {
+			queries = append(queries, text.Query)
+		}
+	}
+
+	return getQueryTerms(strings.Join(queries, " "))
+}
+
+// getRanked will get the filtered entries ordered by the term frequencies of the text filters
+// Note: LastID represents the score and entry ID of the last entry
+func (t *Transaction) getRanked(o *FilteringOpts, onEntry func(Value)) (lastID string, err error) {
+	terms := getRankingTerms(o.Filters)
+	if len(terms) == 0 {
+		err = ErrMissingTextFilter
+		return
+	}
+
+	termBkts := make([]backend.Bucket, 0, len(terms))
+	for _, term := range terms {
+		var termBkt backend.Bucket
+		if termBkt, err = t.getTermBucket(term); err != nil {
+			return
+		}
+
+		if termBkt != nil {
+			termBkts = append(termBkts, termBkt)
+		}
+	}
+
+	var c IDCursor
+	if c, err = t.idCursor(&o.IteratingOpts); err != nil {
+		return
+	}
+
+	// LastID represents the ranked position rather than the position of the cursor
+	iteratingOpts := o.IteratingOpts
+	iteratingOpts.LastID = ""
+
+	var position *rankedEntry
+	if len(o.LastID) > 0 {
+		var p rankedEntry
+		if p, err = parseRankedPosition(o.LastID); err != nil {
+			return
+		}
+
+		position = &p
+	}
+
+	// Only the entries of the requested page are held while ranking
+	ranked := newRankedEntries(o.Limit, o.Reverse)
+	if err = t.forEachIDWithCursor(c, &iteratingOpts, func(entryID string) (err error) {
+		entry := rankedEntry{entryID: entryID}
+		for _, termBkt := range termBkts {
+			entry.score += getCount(termBkt, []byte(entryID))
+		}
+
+		if position == nil || position.isBefore(entry, o.Reverse) {
+			ranked.add(entry)
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	var count int64
+	for _, entry := range ranked.sorted() {
+		val := t.m.newEntryValue()
+		if err = t.get([]byte(entry.entryID), val); err != nil {
+			return
+		}
+
+		onEntry(val)
+		if count++; count == o.Limit {
+			lastID = joinSeekID(strconv.FormatInt(entry.score, 10), entry.entryID)
+			return
+		}
+	}
+
+	return
+}
+
+func parseRankedPosition(lastID string) (position rankedEntry, err error) {
+	score, entryID := splitSeekID([]byte(lastID))
+	if position.score, err = strconv.ParseInt(string(score), 10, 64); err != nil {
+		err = ErrInvalidRankedLastID
+		return
+	}
+
+	position.entryID = string(entryID)
+	return
+}
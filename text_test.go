@@ -0,0 +1,188 @@
+package mojura
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mojura/mojura/filters"
+)
+
+func TestMojura_IndexText(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.IndexText = true
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	values := []string{
+		"The quick brown fox jumps over the lazy dog",
+		"Foxes are running through the forest",
+		"A lazy afternoon",
+		"The fox jumped, the fox ran, the fox hid",
+	}
+
+	for i, value := range values {
+		userID := "user_0"
+		if i == 3 {
+			userID = "user_1"
+		}
+
+		if _, err = m.New(newTestStruct(userID, "contact_0", "group_0", value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Text("fox")},
+			expected: []string{"00000000", "00000001", "00000003"},
+		},
+		{
+			filters:  []Filter{filters.Text("FOX jumping")},
+			reverse:  true,
+			expected: []string{"00000003", "00000000"},
+		},
+		{
+			filters:  []Filter{filters.Text("fox"), filters.Match("users", "user_0")},
+			expected: []string{"00000000", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Or(filters.Text("lazy"), filters.Text("forest"))},
+			expected: []string{"00000000", "00000001", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.Text("elephant")},
+			expected: []string{},
+		},
+		{
+			filters:  []Filter{filters.Text("...")},
+			expected: []string{},
+		},
+	})
+
+	o := NewFilteringOpts(filters.Text("fox"))
+	o.RankByText = true
+	o.Limit = 2
+
+	var entries []*testStruct
+	if o.LastID, err = m.GetFiltered(&entries, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckIDs(entries, []string{"00000003", "00000000"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries = entries[:0]
+	if o.LastID, err = m.GetFiltered(&entries, o); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testCheckIDs(entries, []string{"00000001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Edits and removals keep the index in sync
+	if err = m.Edit("00000002", newTestStruct("user_0", "contact_0", "group_0", "A fox in the afternoon")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Remove("00000003"); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Text("fox")},
+			expected: []string{"00000000", "00000001", "00000002"},
+		},
+		{
+			filters:  []Filter{filters.Text("lazy")},
+			expected: []string{"00000000"},
+		},
+	})
+
+	if _, err = m.GetPage(&entries, o); err != ErrRankedPageToken {
+		t.Fatalf("invalid error, expected %v and received %v", ErrRankedPageToken, err)
+	}
+
+	o = NewFilteringOpts(filters.Match("users", "user_0"))
+	o.RankByText = true
+	if _, err = m.GetFiltered(&entries, o); err != ErrMissingTextFilter {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMissingTextFilter, err)
+	}
+}
+
+func TestMojura_IndexText_backfill(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = m.New(newTestStruct("user_0", "contact_0", "group_0", "Searching for entries")); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []*testStruct
+	if _, err = m.GetFiltered(&entries, NewFilteringOpts(filters.Text("entry"))); err != ErrTextNotIndexed {
+		t.Fatalf("invalid error, expected %v and received %v", ErrTextNotIndexed, err)
+	}
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := defaultOpts
+	opts.IndexText = true
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.Text("search entry")},
+			expected: []string{"00000000"},
+		},
+	})
+}
+
+func Test_getTerms(t *testing.T) {
+	type testcase struct {
+		text     string
+		expected []string
+	}
+
+	tcs := []testcase{
+		{text: "", expected: nil},
+		{text: "Hello, World!", expected: []string{"hello", "world"}},
+		{text: "Running runners ran", expected: []string{"run", "runner", "ran"}},
+		{text: "Classes, ponies and glasses", expected: []string{"class", "pony", "and", "glass"}},
+		{text: "Jumped jumping jumps", expected: []string{"jump", "jump", "jump"}},
+		{text: "Foxes, watches and wishes", expected: []string{"fox", "watch", "and", "wish"}},
+		{text: "Status bus is red", expected: []string{"status", "bus", "is", "red"}},
+		{text: "Café déjà-vu 42", expected: []string{"café", "déjà", "vu", "42"}},
+	}
+
+	for _, tc := range tcs {
+		if terms := getTerms(tc.text); !reflect.DeepEqual(terms, tc.expected) {
+			t.Fatalf("invalid terms for <%s>, expected %v and received %v", tc.text, tc.expected, terms)
+		}
+	}
+}
+
+func TestNew_reserved_text_relationship_key(t *testing.T) {
+	if _, err := newMojura(&testStruct{}, defaultOpts, []string{"users", filters.TextKey, "groups", "tags"}); err != ErrReservedRelationshipKey {
+		t.Fatalf("invalid error, expected %v and received %v", ErrReservedRelationshipKey, err)
+	}
+}
@@ -0,0 +1,135 @@
+package mojura
+
+import (
+	"bytes"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+var (
+	createdAtRelationshipKey = []byte(filters.CreatedAtKey)
+	updatedAtRelationshipKey = []byte(filters.UpdatedAtKey)
+)
+
+func isTimestampRelationship(relationship []byte) (ok bool) {
+	return bytes.Equal(relationship, createdAtRelationshipKey) || bytes.Equal(relationship, updatedAtRelationshipKey)
+}
+
+func getTimestampRelationshipIDs(val Value) (createdAt, updatedAt []byte) {
+	createdAt = []byte(filters.Encode(val.GetCreatedAt()))
+	updatedAt = []byte(filters.Encode(val.GetUpdatedAt()))
+	return
+}
+
+// initTimestampBuckets will create (and backfill) the timestamp indexes when enabled, and remove them when disabled
+// Note: When either timestamp index is missing, both indexes are rebuilt
+func (m *Mojura) initTimestampBuckets(root backend.Transaction, relationshipsBkt, countsBkt backend.Bucket) (err error) {
+	if m.opts.IndexTimestamps && hasTimestampBuckets(relationshipsBkt) {
+		// Indexes already exist
+		return
+	}
+
+	for _, relationship := range [][]byte{createdAtRelationshipKey, updatedAtRelationshipKey} {
+		// Remove any existing (and potentially stale or partial) index
+		if err = deleteBucketIfExists(relationshipsBkt, relationship); err != nil {
+			return
+		}
+
+		if err = deleteBucketIfExists(countsBkt, relationship); err != nil {
+			return
+		}
+	}
+
+	if !m.opts.IndexTimestamps {
+		return
+	}
+
+	var createdAtBkt, updatedAtBkt backend.Bucket
+	if createdAtBkt, err = relationshipsBkt.GetOrCreateBucket(createdAtRelationshipKey); err != nil {
+		return
+	}
+
+	if updatedAtBkt, err = relationshipsBkt.GetOrCreateBucket(updatedAtRelationshipKey); err != nil {
+		return
+	}
+
+	// Index entries which existed before the timestamp indexes were created
+	if err = root.GetBucket(entriesBktKey).ForEach(func(entryID, bs []byte) (err error) {
+		var val Value
+		if val, err = m.newValueFromBytes(bs); err != nil {
+			return
+		}
+
+		createdAt, updatedAt := getTimestampRelationshipIDs(val)
+		if err = putRelationshipKey(createdAtBkt, createdAt, entryID); err != nil {
+			return
+		}
+
+		return putRelationshipKey(updatedAtBkt, updatedAt, entryID)
+	}); err != nil {
+		return
+	}
+
+	if err = initCountsBucket(countsBkt, createdAtBkt, createdAtRelationshipKey); err != nil {
+		return
+	}
+
+	return initCountsBucket(countsBkt, updatedAtBkt, updatedAtRelationshipKey)
+}
+
+func hasTimestampBuckets(relationshipsBkt backend.Bucket) (ok bool) {
+	return relationshipsBkt.GetBucket(createdAtRelationshipKey) != nil && relationshipsBkt.GetBucket(updatedAtRelationshipKey) != nil
+}
+
+// setTimestamps will update the timestamp indexes for an entry
+// Note: orig is nil when the entry did not previously exist
+func (t *Transaction) setTimestamps(entryID []byte, orig, val Value) (err error) {
+	if !t.m.opts.IndexTimestamps {
+		return
+	}
+
+	if orig != nil {
+		if err = t.unsetTimestamps(entryID, orig); err != nil {
+			return
+		}
+	}
+
+	createdAt, updatedAt := getTimestampRelationshipIDs(val)
+	if err = t.setRelationship(createdAtRelationshipKey, createdAt, entryID); err != nil {
+		return
+	}
+
+	return t.setRelationship(updatedAtRelationshipKey, updatedAt, entryID)
+}
+
+// unsetTimestamps will remove an entry from the timestamp indexes
+func (t *Transaction) unsetTimestamps(entryID []byte, val Value) (err error) {
+	if !t.m.opts.IndexTimestamps {
+		return
+	}
+
+	createdAt, updatedAt := getTimestampRelationshipIDs(val)
+	if err = t.unsetRelationship(createdAtRelationshipKey, createdAt, entryID); err != nil {
+		return
+	}
+
+	return t.unsetRelationship(updatedAtRelationshipKey, updatedAt, entryID)
+}
+
+func putRelationshipKey(relationshipBkt backend.Bucket, relationshipID, entryID []byte) (err error) {
+	var bkt backend.Bucket
+	if bkt, err = relationshipBkt.GetOrCreateBucket(relationshipID); err != nil {
+		return
+	}
+
+	return bkt.Put(entryID, nil)
+}
+
+func deleteBucketIfExists(parent backend.Bucket, key []byte) (err error) {
+	if parent.GetBucket(key) == nil {
+		return
+	}
+
+	return parent.DeleteBucket(key)
+}
@@ -0,0 +1,181 @@
+package mojura
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
+)
+
+func TestMojura_IndexTimestamps(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.IndexTimestamps = true
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		entry := newTestStruct("user_0", "contact_0", "group_0", "")
+		entry.CreatedAt = base.Add(time.Hour * 24 * time.Duration(i)).Unix()
+		if i == 3 {
+			entry.UserID = "user_1"
+		}
+
+		if entry.ID, err = m.New(entry); err != nil {
+			t.Fatal(err)
+		}
+
+		// Edit the entry to ensure stale updated at timestamps are removed
+		if err = m.Edit(entry.ID, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.CreatedBetween(base.Add(time.Hour*24), base.Add(time.Hour*24*3))},
+			expected: []string{"00000001", "00000002", "00000003"},
+		},
+		{
+			filters:  []Filter{filters.CreatedBetween(base.Add(time.Hour*24), base.Add(time.Hour*24*3)), filters.Match("users", "user_0")},
+			reverse:  true,
+			expected: []string{"00000002", "00000001"},
+		},
+		{
+			filters:  []Filter{filters.Match("users", "user_1"), filters.UpdatedSince(time.Now().Add(-time.Hour))},
+			expected: []string{"00000003"},
+		},
+		{
+			filters:  []Filter{filters.UpdatedSince(time.Now().Add(time.Hour))},
+			expected: []string{},
+		},
+	})
+
+	if err = m.ReadTransaction(context.Background(), func(txn *Transaction) (err error) {
+		var e filterEstimate
+		if e, err = txn.estimateFilter(filters.UpdatedSince(time.Unix(0, 0))); err != nil {
+			return
+		}
+
+		if e.scan != 4 {
+			t.Fatalf("invalid updated at count, expected %d and received %d", 4, e.scan)
+		}
+
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Remove("00000000"); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.CreatedBetween(base, base.Add(time.Hour*24))},
+			expected: []string{"00000001"},
+		},
+	})
+}
+
+func TestMojura_IndexTimestamps_backfill(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	if m, err = testInit(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := newTestStruct("user_0", "contact_0", "group_0", "")
+	entry.CreatedAt = 100
+	if _, err = m.New(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []*testStruct
+	if _, err = m.GetFiltered(&entries, NewFilteringOpts(filters.UpdatedSince(time.Unix(0, 0)))); err != ErrTimestampsNotIndexed {
+		t.Fatalf("invalid error, expected %v and received %v", ErrTimestampsNotIndexed, err)
+	}
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := defaultOpts
+	opts.IndexTimestamps = true
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer testTeardown(m)
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.CreatedBetween(time.Unix(0, 0), time.Unix(100, 0))},
+			expected: []string{"00000000"},
+		},
+	})
+}
+
+func TestMojura_IndexTimestamps_partial(t *testing.T) {
+	var (
+		m   *Mojura
+		err error
+	)
+
+	opts := defaultOpts
+	opts.IndexTimestamps = true
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { testTeardown(m) }()
+
+	entry := newTestStruct("user_0", "contact_0", "group_0", "")
+	entry.CreatedAt = 100
+	entry.UpdatedAt = 200
+	if _, err = m.New(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the updated at index to simulate a DB which was closed while the indexes were being created
+	if err = m.db.Transaction(func(txn backend.Transaction) (err error) {
+		return txn.GetBucket(relationshipsBktKey).DeleteBucket(updatedAtRelationshipKey)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err = testInitWithOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	testFilteredCases(t, m, []testFilteredCase{
+		{
+			filters:  []Filter{filters.CreatedBetween(time.Unix(0, 0), time.Unix(100, 0))},
+			expected: []string{"00000000"},
+		},
+		{
+			filters:  []Filter{filters.UpdatedSince(time.Unix(150, 0))},
+			expected: []string{"00000000"},
+		},
+	})
+}
+
+func TestNew_reserved_relationship_key(t *testing.T) {
+	if _, err := newMojura(&testStruct{}, defaultOpts, []string{"users", filters.CreatedAtKey, "groups", "tags"}); err != ErrReservedRelationshipKey {
+		t.Fatalf("invalid error, expected %v and received %v", ErrReservedRelationshipKey, err)
+	}
+}
@@ -8,12 +8,14 @@ import (
 
 	"github.com/gdbu/actions"
 	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
 )
 
-func newTransaction(ctx context.Context, m *Mojura, txn backend.Transaction, atxn *actions.Transaction) (t Transaction) {
+func newTransaction(cc *contextContainer, m *Mojura, txn backend.Transaction, atxn *actions.Transaction) (t Transaction) {
 	t.m = m
-	t.cc = newContextContainer(ctx)
+	t.cc = cc
 	t.txn = txn
+	t.root = getRoot(txn, m.namespace)
 	t.atxn = atxn
 	return
 }
@@ -25,21 +27,58 @@ type Transaction struct {
 	cc *contextContainer
 
 	txn  backend.Transaction
+	root backend.Transaction
 	atxn *actions.Transaction
 }
 
+func (t *Transaction) withContext(ctx context.Context) (out *Transaction) {
+	// Create a shallow copy of the transaction
+	clone := *t
+	// Set the context container of the clone as a child container using the provided context
+	clone.cc = t.cc.withContext(ctx)
+	return &clone
+}
+
 func (t *Transaction) getRelationshipBucket(relationship []byte) (bkt backend.Bucket, err error) {
 	if err = t.cc.isDone(); err != nil {
 		return
 	}
 
 	var relationshipsBkt backend.Bucket
-	if relationshipsBkt = t.txn.GetBucket(relationshipsBktKey); relationshipsBkt == nil {
+	if relationshipsBkt = t.root.GetBucket(relationshipsBktKey); relationshipsBkt == nil {
 		err = ErrNotInitialized
 		return
 	}
 
 	if bkt = relationshipsBkt.GetBucket(relationship); bkt == nil {
+		switch {
+		case isTimestampRelationship(relationship):
+			err = ErrTimestampsNotIndexed
+		case isTextRelationship(relationship):
+			err = ErrTextNotIndexed
+
+		default:
+			err = ErrRelationshipNotFound
+		}
+
+		return
+	}
+
+	return
+}
+
+func (t *Transaction) getMissingBucket(relationship []byte) (bkt backend.Bucket, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	var missingBkt backend.Bucket
+	if missingBkt = t.root.GetBucket(missingBktKey); missingBkt == nil {
+		err = ErrNotInitialized
+		return
+	}
+
+	if bkt = missingBkt.GetBucket(relationship); bkt == nil {
 		err = ErrRelationshipNotFound
 		return
 	}
@@ -47,12 +86,53 @@ func (t *Transaction) getRelationshipBucket(relationship []byte) (bkt backend.Bu
 	return
 }
 
+func (t *Transaction) getCountsBucket(relationship []byte) (bkt backend.Bucket, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	var countsBkt backend.Bucket
+	if countsBkt = t.root.GetBucket(countsBktKey); countsBkt == nil {
+		err = ErrNotInitialized
+		return
+	}
+
+	if bkt = countsBkt.GetBucket(relationship); bkt == nil {
+		err = ErrRelationshipNotFound
+		return
+	}
+
+	return
+}
+
+func (t *Transaction) getStatsBucket() (bkt backend.Bucket, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	if bkt = t.root.GetBucket(statsBktKey); bkt == nil {
+		err = ErrNotInitialized
+		return
+	}
+
+	return
+}
+
+func (t *Transaction) addStat(key []byte, delta int64) (err error) {
+	var bkt backend.Bucket
+	if bkt, err = t.getStatsBucket(); err != nil {
+		return
+	}
+
+	return addCount(bkt, key, delta)
+}
+
 func (t *Transaction) getEntriesBucket() (bkt backend.Bucket, err error) {
 	if err = t.cc.isDone(); err != nil {
 		return
 	}
 
-	if bkt = t.txn.GetBucket(entriesBktKey); bkt == nil {
+	if bkt = t.root.GetBucket(entriesBktKey); bkt == nil {
 		err = ErrNotInitialized
 		return
 	}
@@ -84,20 +164,30 @@ func (t *Transaction) getBytes(entryID []byte) (bs []byte, err error) {
 	return
 }
 
-func (t *Transaction) idCursor(fs []Filter) (c IDCursor, err error) {
-	if len(fs) == 0 {
+func (t *Transaction) idCursor(o *IteratingOpts) (c IDCursor, err error) {
+	if len(o.Filters) == 0 && o.OrderBy == nil {
 		return newBaseIDCursor(t)
 	}
 
-	return newMultiIDCursor(t, fs)
+	var p queryPlan
+	if p, err = t.getQueryPlan(o); err != nil {
+		return
+	}
+
+	return newMultiIDCursorFromPlan(t, p)
 }
 
-func (t *Transaction) cursor(fs []Filter) (c Cursor, err error) {
-	if len(fs) == 0 {
+func (t *Transaction) cursor(o *IteratingOpts) (c Cursor, err error) {
+	if len(o.Filters) == 0 && o.OrderBy == nil {
 		return newBaseCursor(t)
 	}
 
-	return newMultiCursor(t, fs)
+	var p queryPlan
+	if p, err = t.getQueryPlan(o); err != nil {
+		return
+	}
+
+	return newMultiCursorFromPlan(t, p)
 }
 
 func (t *Transaction) exists(entryID []byte) (ok bool, err error) {
@@ -106,7 +196,7 @@ func (t *Transaction) exists(entryID []byte) (ok bool, err error) {
 	}
 
 	var bkt backend.Bucket
-	if bkt = t.txn.GetBucket(entriesBktKey); bkt == nil {
+	if bkt = t.root.GetBucket(entriesBktKey); bkt == nil {
 		err = ErrNotInitialized
 		return
 	}
@@ -122,7 +212,7 @@ func (t *Transaction) insertEntry(entryID []byte, val Value) (err error) {
 	}
 
 	var bkt backend.Bucket
-	if bkt = t.txn.GetBucket(entriesBktKey); bkt == nil {
+	if bkt = t.root.GetBucket(entriesBktKey); bkt == nil {
 		return ErrNotInitialized
 	}
 
@@ -142,7 +232,7 @@ func (t *Transaction) delete(entryID []byte) (err error) {
 	}
 
 	var bkt backend.Bucket
-	if bkt = t.txn.GetBucket(entriesBktKey); bkt == nil {
+	if bkt = t.root.GetBucket(entriesBktKey); bkt == nil {
 		return ErrNotInitialized
 	}
 
@@ -154,7 +244,7 @@ func (t *Transaction) setRelationships(relationships Relationships, entryID []by
 		return
 	}
 
-	for i, relationship := range relationships {
+	for i, relationship := range t.m.normalizeRelationships(relationships) {
 		relationshipKey := t.m.relationships[i]
 		for _, relationshipID := range relationship {
 			if err = t.setRelationship(relationshipKey, []byte(relationshipID), entryID); err != nil {
@@ -186,7 +276,81 @@ func (t *Transaction) setRelationship(relationship, relationshipID, entryID []by
 		return
 	}
 
-	return bkt.Put(entryID, nil)
+	if hasKey(bkt, entryID) {
+		// Relationship is already set
+		return
+	}
+
+	if err = bkt.Put(entryID, nil); err != nil {
+		return
+	}
+
+	var countsBkt backend.Bucket
+	if countsBkt, err = t.getCountsBucket(relationship); err != nil {
+		return
+	}
+
+	return addCount(countsBkt, relationshipID, 1)
+}
+
+// setMissing will update the missing index of each relationship key for the provided entry
+// Note: Relationships are checked in their normalized form, as they are indexed
+func (t *Transaction) setMissing(relationships Relationships, entryID []byte) (err error) {
+	relationships = t.m.normalizeRelationships(relationships)
+	for i, relationshipKey := range t.m.relationships {
+		var bkt backend.Bucket
+		if bkt, err = t.getMissingBucket(relationshipKey); err != nil {
+			return
+		}
+
+		isMissing := isMissingRelationship(relationships, i)
+		if isMissing == hasKey(bkt, entryID) {
+			// Missing index is already up to date
+			continue
+		}
+
+		delta := int64(1)
+		if isMissing {
+			err = bkt.Put(entryID, nil)
+		} else {
+			err = bkt.Delete(entryID)
+			delta = -1
+		}
+
+		if err != nil {
+			return
+		}
+
+		if err = t.addStat(getMissingStatKey(relationshipKey), delta); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// unsetMissing will remove the provided entry from the missing index of each relationship key
+func (t *Transaction) unsetMissing(entryID []byte) (err error) {
+	for _, relationshipKey := range t.m.relationships {
+		var bkt backend.Bucket
+		if bkt, err = t.getMissingBucket(relationshipKey); err != nil {
+			return
+		}
+
+		if !hasKey(bkt, entryID) {
+			continue
+		}
+
+		if err = bkt.Delete(entryID); err != nil {
+			return
+		}
+
+		if err = t.addStat(getMissingStatKey(relationshipKey), -1); err != nil {
+			return
+		}
+	}
+
+	return
 }
 
 func (t *Transaction) unsetRelationships(relationships Relationships, entryID []byte) (err error) {
@@ -194,7 +358,7 @@ func (t *Transaction) unsetRelationships(relationships Relationships, entryID []
 		return
 	}
 
-	for i, relationship := range relationships {
+	for i, relationship := range t.m.normalizeRelationships(relationships) {
 		relationshipKey := t.m.relationships[i]
 		for _, relationshipID := range relationship {
 			if err = t.unsetRelationship(relationshipKey, []byte(relationshipID), entryID); err != nil {
@@ -223,11 +387,25 @@ func (t *Transaction) unsetRelationship(relationship, relationshipID, entryID []
 		return
 	}
 
+	if !hasKey(bkt, entryID) {
+		// Relationship is not set
+		return
+	}
+
 	// Delete entry in bucket by entry ID
 	if err = bkt.Delete(entryID); err != nil {
 		return
 	}
 
+	var countsBkt backend.Bucket
+	if countsBkt, err = t.getCountsBucket(relationship); err != nil {
+		return
+	}
+
+	if err = addCount(countsBkt, relationshipID, -1); err != nil {
+		return
+	}
+
 	// Check to see if relationship ID bucket has any entries left
 	if hasEntries(bkt) {
 		// Bucket has entries, return
@@ -243,8 +421,9 @@ func (t *Transaction) updateRelationships(entryID []byte, orig, val Value) (err
 		return
 	}
 
-	origRelationships := orig.GetRelationships()
-	newRelationships := val.GetRelationships()
+	// Relationships are compared in their normalized form, as they are indexed
+	origRelationships := t.m.normalizeRelationships(orig.GetRelationships())
+	newRelationships := t.m.normalizeRelationships(val.GetRelationships())
 
 	for i, relationship := range newRelationships {
 		onAdd := func(relationshipID []byte) (err error) {
@@ -265,12 +444,12 @@ func (t *Transaction) updateRelationships(entryID []byte, orig, val Value) (err
 // Note: Will return ErrEntryNotFound if no match is found
 func (t *Transaction) getFirst(value Value, o *IteratingOpts) (err error) {
 	var cur IDCursor
-	if cur, err = t.idCursor(o.Filters); err != nil {
+	if cur, err = t.idCursor(o); err != nil {
 		return
 	}
 
 	var entryID string
-	if entryID, err = getFirstID(cur, o.LastID, false); err == Break {
+	if entryID, err = getFirstID(cur, o.LastID, o.applyOrder(false)); err == Break {
 		return ErrEntryNotFound
 	} else if err != nil {
 		return
@@ -283,12 +462,12 @@ func (t *Transaction) getFirst(value Value, o *IteratingOpts) (err error) {
 // Note: Will return ErrEntryNotFound if no match is found
 func (t *Transaction) getLast(value Value, o *IteratingOpts) (err error) {
 	var cur IDCursor
-	if cur, err = t.idCursor(o.Filters); err != nil {
+	if cur, err = t.idCursor(o); err != nil {
 		return
 	}
 
 	var entryID string
-	if entryID, err = getFirstID(cur, o.LastID, true); err == Break {
+	if entryID, err = getFirstID(cur, o.LastID, o.applyOrder(true)); err == Break {
 		return ErrEntryNotFound
 	} else if err != nil {
 		return
@@ -297,7 +476,7 @@ func (t *Transaction) getLast(value Value, o *IteratingOpts) (err error) {
 	return t.get([]byte(entryID), value)
 }
 
-func (t *Transaction) getFiltered(es reflect.Value, o *FilteringOpts) (lastID string, err error) {
+func (t *Transaction) getFiltered(o *FilteringOpts, onEntry func(Value)) (lastID string, err error) {
 	if o == nil {
 		o = defaultFilteringOpts
 	}
@@ -306,19 +485,161 @@ func (t *Transaction) getFiltered(es reflect.Value, o *FilteringOpts) (lastID st
 		return
 	}
 
+	defer t.logSlowQuery(o.Filters, time.Now())
+
+	if o.RankByText {
+		return t.getRanked(o, onEntry)
+	}
+
 	var c Cursor
-	if c, err = t.cursor(o.Filters); err != nil {
+	if c, err = t.cursor(&o.IteratingOpts); err != nil {
+		return
+	}
+
+	return t.getFilteredWithCursor(c, o, onEntry)
+}
+
+func (t *Transaction) getPage(o *FilteringOpts, onEntry func(Value)) (page Page, err error) {
+	if o == nil {
+		o = defaultFilteringOpts
+	}
+
+	defer t.logSlowQuery(o.Filters, time.Now())
+
+	if o.RankByText {
+		err = ErrRankedPageToken
+		return
+	}
+
+	var hash []byte
+	if hash, err = getPageTokenHash(o); err != nil {
+		return
+	}
+
+	var tkn pageToken
+	if len(o.PageToken) > 0 {
+		if err = tkn.unmarshal(t.m.pageTokenKey, o.PageToken); err != nil {
+			return
+		}
+
+		if err = tkn.validate(hash); err != nil {
+			return
+		}
+	} else {
+		tkn.reverse = o.applyOrder(o.Reverse)
+		tkn.hash = hash
+		tkn.pin = -1
+	}
+
+	if o.Limit == 0 {
+		return
+	}
+
+	// Positions are resumed with the primary they were created with
+	var p queryPlan
+	if p, err = t.getPinnedQueryPlan(&o.IteratingOpts, tkn.pin); err != nil {
+		return
+	}
+
+	// Token holds the direction of the query
+	p.reverse = tkn.reverse
+
+	var mid *multiIDCursor
+	if mid, err = newMultiIDCursorFromPlan(t, p); err != nil {
+		return
+	}
+
+	c := &multiCursor{txn: t, mid: mid}
+	// Backward pages are iterated in the opposite direction and then reversed
+	reverse := tkn.isReverse()
+	iterator := getIteratorFunc(c, reverse)
+
+	var val Value
+	if tkn.hasPosition() {
+		val, err = c.seekPast(tkn.relationshipID, tkn.entryID, reverse)
+	} else {
+		val, err = getFirst(c, "", reverse)
+	}
+
+	var (
+		vals        []Value
+		first, last pageToken
+	)
+
+	for ; err == nil; val, err = iterator() {
+		position := pageToken{pin: mid.pin, relationshipID: c.getCurrentRelationshipID(), entryID: val.GetID()}
+		if len(vals) == 0 {
+			first = position
+		}
+
+		last = position
+		if vals = append(vals, val); int64(len(vals)) == o.Limit {
+			break
+		}
+	}
+
+	var hasMore bool
+	if err == nil {
+		// Limit has been reached, peek (without decoding) to determine if more entries exist
+		peek := c.mid.next
+		if reverse {
+			peek = c.mid.prev
+		}
+
+		_, err = peek()
+		hasMore = err == nil
+	}
+
+	if err == Break {
+		err = nil
+	}
+
+	if err != nil || len(vals) == 0 {
 		return
 	}
 
+	hasNext, hasPrevious := hasMore, tkn.hasPosition()
+	if tkn.backward {
+		reverseValues(vals)
+		first, last = last, first
+		hasNext, hasPrevious = true, hasMore
+	}
+
+	for _, val := range vals {
+		onEntry(val)
+	}
+
+	if hasNext {
+		last.reverse, last.hash = tkn.reverse, hash
+		page.Next = last.marshal(t.m.pageTokenKey)
+	}
+
+	if hasPrevious {
+		first.reverse, first.backward, first.hash = tkn.reverse, true, hash
+		page.Previous = first.marshal(t.m.pageTokenKey)
+	}
+
+	return
+}
+
+func (t *Transaction) logSlowQuery(fs []Filter, start time.Time) {
+	threshold := t.m.opts.SlowQueryThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	if elapsed := time.Since(start); elapsed >= threshold {
+		t.m.opts.Logger.Printf("mojura: slow query <%s> took %v\n", describeFilters(fs), elapsed)
+	}
+}
+
+func (t *Transaction) getFilteredWithCursor(c Cursor, o *FilteringOpts, onEntry func(Value)) (lastID string, err error) {
 	var count int64
 	err = t.forEachWithCursor(c, &o.IteratingOpts, func(entryID string, val Value) (err error) {
-		rVal := reflect.ValueOf(val)
-		appended := reflect.Append(es, rVal)
-		es.Set(appended)
+		onEntry(val)
 
 		if count++; count == o.Limit {
-			lastID = joinSeekID(c.getCurrentRelationshipID(), entryID)
+			lastID = getLastID(c, entryID)
 			return Break
 		}
 
@@ -328,10 +649,142 @@ func (t *Transaction) getFiltered(es reflect.Value, o *FilteringOpts) (lastID st
 	return
 }
 
+func (t *Transaction) explain(o *FilteringOpts) (e *Explanation, err error) {
+	if o == nil {
+		o = defaultFilteringOpts
+	}
+
+	var ex Explanation
+	start := time.Now()
+	ex.Query = describeFilters(o.Filters)
+
+	var p queryPlan
+	if p, err = t.getQueryPlan(&o.IteratingOpts); err != nil {
+		return
+	}
+
+	ex.Primary = p.primary
+	ex.Secondary = p.secondary
+	ex.PostFilters = make([]Filter, len(p.predicates))
+	for i, predicate := range p.predicates {
+		ex.PostFilters[i] = predicate
+	}
+
+	// Cursors are created in plan order, primary first
+	// Note: Each cursor explanation is allocated separately, as the cursors hold pointers to their counters
+	cursors := make([]*CursorExplanation, 0, len(p.secondary)+1)
+	var mid *multiIDCursor
+	if mid, err = newMultiIDCursorWithFn(t, p, func(txn *Transaction, f Filter, distinct, reverse bool) (fc filterCursor, err error) {
+		ce := &CursorExplanation{Filter: f, Primary: len(cursors) == 0}
+		cursors = append(cursors, ce)
+		if ce.EstimatedKeys, err = t.getExplainEstimate(f); err != nil {
+			return
+		}
+
+		if fc, err = newPlannedFilterCursor(txn.withScanCounter(&ce.KeysScanned), f, distinct, reverse); err != nil {
+			return
+		}
+
+		fc = &probeCountingCursor{filterCursor: fc, counter: &ce.Probes}
+		return
+	}); err != nil {
+		return
+	}
+
+	if len(mid.predicates) > 0 {
+		mid.predicates = []filters.PredicateFn{newRejectionCountingPredicate(mid.predicates, &ex.EntriesRejected)}
+	}
+
+	c := &multiCursor{txn: t, mid: mid}
+	if o.Limit != 0 {
+		if _, err = t.getFilteredWithCursor(c, o, func(Value) {
+			ex.EntriesDecoded++
+		}); err != nil {
+			return
+		}
+	}
+
+	ex.Cursors = make([]CursorExplanation, len(cursors))
+	for i, ce := range cursors {
+		ex.Cursors[i] = *ce
+	}
+
+	// Entries rejected by the post filters were decoded before being rejected
+	ex.EntriesDecoded += ex.EntriesRejected
+	ex.Elapsed = time.Since(start)
+	e = &ex
+	return
+}
+
+func (t *Transaction) getExplainEstimate(f Filter) (estimate int64, err error) {
+	if f == nil {
+		estimate = t.getEntriesCount()
+		return
+	}
+
+	var fe filterEstimate
+	if fe, err = t.estimateFilter(f); err != nil {
+		return
+	}
+
+	estimate = fe.scan
+	return
+}
+
+func (t *Transaction) getIncluded(o *FilteringOpts, vals []Value) (included Included, err error) {
+	included = make(Included)
+	if o == nil {
+		return
+	}
+
+	for _, relationshipKey := range o.Include {
+		var index int
+		if index, err = t.m.getRelationshipIndex([]byte(relationshipKey)); err != nil {
+			return
+		}
+
+		// Related entries are resolved within the same transaction
+		target := t.sibling(t.m.getReferenceTarget(index))
+		for _, val := range vals {
+			if err = target.include(included, relationshipKey, val.GetRelationships(), index); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (t *Transaction) include(included Included, relationshipKey string, relationships Relationships, index int) (err error) {
+	if index >= len(relationships) {
+		return
+	}
+
+	for _, relationshipID := range relationships[index] {
+		if len(relationshipID) == 0 || included.has(relationshipKey, relationshipID) {
+			continue
+		}
+
+		val := t.m.newEntryValue()
+		switch err = t.get([]byte(relationshipID), val); err {
+		case nil:
+			included.set(relationshipKey, relationshipID, val)
+		case ErrEntryNotFound:
+			// Related entry does not exist, skip
+			err = nil
+
+		default:
+			return
+		}
+	}
+
+	return
+}
+
 func (t *Transaction) forEachWithCursor(c Cursor, o *IteratingOpts, fn ForEachFn) (err error) {
 	var val Value
-	iterator := getIteratorFunc(c, o.Reverse)
-	val, err = getFirst(c, o.LastID, o.Reverse)
+	iterator := getIteratorFunc(c, o.applyOrder(o.Reverse))
+	val, err = getFirst(c, o.LastID, o.applyOrder(o.Reverse))
 	for err == nil {
 		if err = fn(val.GetID(), val); err != nil {
 			break
@@ -349,8 +802,8 @@ func (t *Transaction) forEachWithCursor(c Cursor, o *IteratingOpts, fn ForEachFn
 
 func (t *Transaction) forEachIDWithCursor(c IDCursor, o *IteratingOpts, fn ForEachIDFn) (err error) {
 	var entryID string
-	iterator := getIDIteratorFunc(c, o.Reverse)
-	entryID, err = getFirstID(c, o.LastID, o.Reverse)
+	iterator := getIDIteratorFunc(c, o.applyOrder(o.Reverse))
+	entryID, err = getFirstID(c, o.LastID, o.applyOrder(o.Reverse))
 	for err == nil {
 		if err = fn(entryID); err != nil {
 			break
@@ -401,15 +854,51 @@ func (t *Transaction) put(entryID []byte, val Value) (err error) {
 		val.SetCreatedAt(time.Now().Unix())
 	}
 
+	if err = t.validateReferences(val.GetRelationships()); err != nil {
+		return
+	}
+
+	var exists bool
+	if exists, err = t.exists(entryID); err != nil {
+		return
+	}
+
+	var orig Value
+	if exists && (t.m.opts.IndexTimestamps || t.m.opts.IndexText) {
+		// The original value is needed to update the timestamp and text indexes
+		orig = t.m.newEntryValue()
+		if err = t.get(entryID, orig); err != nil {
+			return
+		}
+	}
+
 	if err = t.insertEntry(entryID, val); err != nil {
 		return
 	}
 
+	if err = t.setTimestamps(entryID, orig, val); err != nil {
+		return
+	}
+
+	if err = t.setText(entryID, orig, val); err != nil {
+		return
+	}
+
+	if !exists {
+		if err = t.addStat(entriesStatKey, 1); err != nil {
+			return
+		}
+	}
+
 	if err = t.setRelationships(val.GetRelationships(), entryID); err != nil {
 		return
 	}
 
-	if err = t.atxn.LogJSON(actions.ActionCreate, getLogKey(entriesBktKey, entryID), val); err != nil {
+	if err = t.setMissing(val.GetRelationships(), entryID); err != nil {
+		return
+	}
+
+	if err = t.atxn.LogJSON(actions.ActionCreate, getLogKey(t.m.entriesLogKey, entryID), val); err != nil {
 		return
 	}
 
@@ -440,7 +929,7 @@ func (t *Transaction) edit(entryID []byte, val Value) (err error) {
 		return
 	}
 
-	if err = t.atxn.LogJSON(actions.ActionEdit, getLogKey(entriesBktKey, entryID), val); err != nil {
+	if err = t.atxn.LogJSON(actions.ActionEdit, getLogKey(t.m.entriesLogKey, entryID), val); err != nil {
 		return
 	}
 
@@ -458,17 +947,46 @@ func (t *Transaction) remove(entryID []byte) (err error) {
 		return
 	}
 
+	// Ensure the removal is not restricted before any changes are made
+	if err = t.checkRestricted(entryID, make(map[string]struct{})); err != nil {
+		return
+	}
+
 	if err = t.delete(entryID); err != nil {
 		err = fmt.Errorf("error removing entry <%s>: %v", entryID, err)
 		return
 	}
 
+	if err = t.addStat(entriesStatKey, -1); err != nil {
+		err = fmt.Errorf("error updating entry count: %v", err)
+		return
+	}
+
 	if err = t.unsetRelationships(val.GetRelationships(), entryID); err != nil {
 		err = fmt.Errorf("error unsetting relationships: %v", err)
 		return
 	}
 
-	if err = t.atxn.LogJSON(actions.ActionDelete, getLogKey(entriesBktKey, entryID), nil); err != nil {
+	if err = t.unsetMissing(entryID); err != nil {
+		err = fmt.Errorf("error unsetting missing relationships: %v", err)
+		return
+	}
+
+	if err = t.unsetTimestamps(entryID, val); err != nil {
+		err = fmt.Errorf("error unsetting timestamps: %v", err)
+		return
+	}
+
+	if err = t.unsetText(entryID, val); err != nil {
+		err = fmt.Errorf("error unsetting text: %v", err)
+		return
+	}
+
+	if err = t.handleDependents(entryID); err != nil {
+		return
+	}
+
+	if err = t.atxn.LogJSON(actions.ActionDelete, getLogKey(t.m.entriesLogKey, entryID), nil); err != nil {
 		err = fmt.Errorf("error logging transaction actions: %v", err)
 		return
 	}
@@ -476,9 +994,150 @@ func (t *Transaction) remove(entryID []byte) (err error) {
 	return
 }
 
+// sibling will return a transaction for the provided instance of Mojura which shares
+// the underlying transaction and context
+func (t *Transaction) sibling(m *Mojura) (txn *Transaction) {
+	if m == t.m {
+		return t
+	}
+
+	s := newTransaction(t.cc, m, t.txn, t.atxn)
+	return &s
+}
+
+func (t *Transaction) validateReferences(relationships Relationships) (err error) {
+	for _, r := range t.m.references {
+		if r.index >= len(relationships) {
+			continue
+		}
+
+		target := t.sibling(r.target)
+		for _, relationshipID := range relationships[r.index] {
+			if len(relationshipID) == 0 {
+				// Unset relationship IDs can be ignored
+				continue
+			}
+
+			var exists bool
+			if exists, err = target.exists([]byte(relationshipID)); err != nil {
+				return
+			}
+
+			if !exists {
+				return ErrReferencedEntryNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (t *Transaction) getDependentIDs(relationshipKey, entryID []byte) (dependentIDs [][]byte, err error) {
+	var relationshipBkt backend.Bucket
+	if relationshipBkt, err = t.getRelationshipBucket(relationshipKey); err != nil {
+		return
+	}
+
+	var bkt backend.Bucket
+	if bkt = relationshipBkt.GetBucket(entryID); bkt == nil {
+		return
+	}
+
+	err = bkt.ForEach(func(dependentID, _ []byte) (err error) {
+		// Copy the dependent ID, as the bucket may be modified while handling dependents
+		dependentIDs = append(dependentIDs, append([]byte(nil), dependentID...))
+		return
+	})
+
+	return
+}
+
+// checkRestricted will ensure the removal of an entry (and any entries removed by cascade) is not
+// restricted by dependent entries
+func (t *Transaction) checkRestricted(entryID []byte, checked map[string]struct{}) (err error) {
+	key := string(t.m.namespace) + "::" + string(entryID)
+	if _, ok := checked[key]; ok {
+		// Entry has already been checked, return
+		return
+	}
+
+	checked[key] = struct{}{}
+	for _, d := range t.m.dependents {
+		if d.policy == ReferenceUnset {
+			// Unset dependents cannot restrict a removal
+			continue
+		}
+
+		source := t.sibling(d.source)
+
+		var dependentIDs [][]byte
+		if dependentIDs, err = source.getDependentIDs(d.relationshipKey, entryID); err != nil {
+			return
+		}
+
+		for _, dependentID := range dependentIDs {
+			if d.policy == ReferenceRestrict {
+				return ErrReferenceRestricted
+			}
+
+			// Dependent will be removed by cascade, ensure its removal is not restricted
+			if err = source.checkRestricted(dependentID, checked); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (t *Transaction) handleDependents(entryID []byte) (err error) {
+	for _, d := range t.m.dependents {
+		source := t.sibling(d.source)
+
+		var dependentIDs [][]byte
+		if dependentIDs, err = source.getDependentIDs(d.relationshipKey, entryID); err != nil {
+			return
+		}
+
+		for _, dependentID := range dependentIDs {
+			if err = source.handleDependent(d, dependentID, entryID); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (t *Transaction) handleDependent(d dependent, dependentID, entryID []byte) (err error) {
+	switch d.policy {
+	case ReferenceRestrict:
+		return ErrReferenceRestricted
+	case ReferenceCascade:
+		var exists bool
+		if exists, err = t.exists(dependentID); err != nil || !exists {
+			// Dependent has already been removed, return
+			return
+		}
+
+		return t.remove(dependentID)
+	case ReferenceUnset:
+		val := t.m.newEntryValue()
+		if err = t.get(dependentID, val); err != nil {
+			return
+		}
+
+		val.(ReferenceUnsetter).UnsetReference(string(d.relationshipKey), string(entryID))
+		return t.edit(dependentID, val)
+
+	default:
+		return ErrInvalidReferencePolicy
+	}
+}
+
 func (t *Transaction) teardown() {
-	t.txn = nil
-	t.m = nil
+	// Mark the transaction (and any child transactions) as unusable
+	t.cc.Close()
 }
 
 // New will insert a new entry with the given value and the associated relationships
@@ -504,12 +1163,74 @@ func (t *Transaction) Get(entryID string, val Value) (err error) {
 
 // GetFiltered will attempt to get all entries associated with a set of given filters
 func (t *Transaction) GetFiltered(entries interface{}, o *FilteringOpts) (lastID string, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
 	var es reflect.Value
 	if es, err = getReflectedSlice(t.m.entryType, entries); err != nil {
 		return
 	}
 
-	return t.getFiltered(es, o)
+	return t.getFiltered(o, func(val Value) {
+		rVal := reflect.ValueOf(val)
+		appended := reflect.Append(es, rVal)
+		es.Set(appended)
+	})
+}
+
+// Explain will execute the filtered query and describe how it was executed
+func (t *Transaction) Explain(o *FilteringOpts) (e *Explanation, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	return t.explain(o)
+}
+
+// GetPage will attempt to get a page of entries associated with a set of given filters along with the
+// tokens of the next and previous pages (see FilteringOpts.PageToken)
+func (t *Transaction) GetPage(entries interface{}, o *FilteringOpts) (page Page, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	var es reflect.Value
+	if es, err = getReflectedSlice(t.m.entryType, entries); err != nil {
+		return
+	}
+
+	return t.getPage(o, func(val Value) {
+		rVal := reflect.ValueOf(val)
+		appended := reflect.Append(es, rVal)
+		es.Set(appended)
+	})
+}
+
+// GetFilteredWithIncluded will attempt to get all entries associated with a set of given filters along with
+// the related entries for the relationship keys set within FilteringOpts.Include
+func (t *Transaction) GetFilteredWithIncluded(entries interface{}, o *FilteringOpts) (lastID string, included Included, err error) {
+	if err = t.cc.isDone(); err != nil {
+		return
+	}
+
+	var es reflect.Value
+	if es, err = getReflectedSlice(t.m.entryType, entries); err != nil {
+		return
+	}
+
+	var vals []Value
+	if lastID, err = t.getFiltered(o, func(val Value) {
+		rVal := reflect.ValueOf(val)
+		appended := reflect.Append(es, rVal)
+		es.Set(appended)
+		vals = append(vals, val)
+	}); err != nil {
+		return
+	}
+
+	included, err = t.getIncluded(o, vals)
+	return
 }
 
 // GetFirst will attempt to get the first entry associated with a set of given filters
@@ -525,13 +1246,15 @@ func (t *Transaction) GetLast(value Value, o *IteratingOpts) (err error) {
 }
 
 // IDCursor will return an ID iterating cursor
+// Note: The first filter which is not an inverse filter is used as the primary filter, the query planner is not used
 func (t *Transaction) IDCursor(fs ...Filter) (c IDCursor, err error) {
-	return t.idCursor(fs)
+	return t.idCursor(newCursorIteratingOpts(fs))
 }
 
 // Cursor will return an iterating cursor
+// Note: The first filter which is not an inverse filter is used as the primary filter, the query planner is not used
 func (t *Transaction) Cursor(fs ...Filter) (c Cursor, err error) {
-	return t.cursor(fs)
+	return t.cursor(newCursorIteratingOpts(fs))
 }
 
 // ForEach will iterate through entries
@@ -541,14 +1264,14 @@ func (t *Transaction) ForEach(fn ForEachFn, o *IteratingOpts) (err error) {
 	}
 
 	var c Cursor
-	if c, err = t.Cursor(o.Filters...); err != nil {
+	if c, err = t.cursor(o); err != nil {
 		return
 	}
 
-	iterator := getIteratorFunc(c, o.Reverse)
+	iterator := getIteratorFunc(c, o.applyOrder(o.Reverse))
 
 	var val Value
-	for val, err = getFirst(c, o.LastID, o.Reverse); err == nil; val, err = iterator() {
+	for val, err = getFirst(c, o.LastID, o.applyOrder(o.Reverse)); err == nil; val, err = iterator() {
 		if err = fn(val.GetID(), val); err != nil {
 			break
 		}
@@ -568,13 +1291,13 @@ func (t *Transaction) ForEachID(fn ForEachIDFn, o *IteratingOpts) (err error) {
 	}
 
 	var c IDCursor
-	if c, err = t.IDCursor(o.Filters...); err != nil {
+	if c, err = t.idCursor(o); err != nil {
 		return
 	}
 
-	iterator := getIDIteratorFunc(c, o.Reverse)
+	iterator := getIDIteratorFunc(c, o.applyOrder(o.Reverse))
 	var entryID string
-	for entryID, err = getFirstID(c, o.LastID, o.Reverse); err == nil; entryID, err = iterator() {
+	for entryID, err = getFirstID(c, o.LastID, o.applyOrder(o.Reverse)); err == nil; entryID, err = iterator() {
 		if err = fn(entryID); err != nil {
 			break
 		}
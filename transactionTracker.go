@@ -0,0 +1,42 @@
+package mojura
+
+import "sync"
+
+// transactionTracker tracks the transactions which are in-flight, allowing the underlying
+// stores to remain open until every transaction has completed
+type transactionTracker struct {
+	mux sync.Mutex
+	wg  sync.WaitGroup
+
+	closed bool
+}
+
+// begin will register a new transaction, false is returned when the tracker has been closed
+func (t *transactionTracker) begin() (ok bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.closed {
+		return false
+	}
+
+	t.wg.Add(1)
+	return true
+}
+
+// end will mark a transaction as completed
+func (t *transactionTracker) end() {
+	t.wg.Done()
+}
+
+// close will stop the tracker from accepting new transactions
+func (t *transactionTracker) close() {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.closed = true
+}
+
+// wait will wait for the in-flight transactions to complete
+// Note: The tracker is expected to be closed before waiting
+func (t *transactionTracker) wait() {
+	t.wg.Wait()
+}
@@ -0,0 +1,50 @@
+package mojura
+
+func newTypedCursor[T Value](cur Cursor) *TypedCursor[T] {
+	var t TypedCursor[T]
+	t.cur = cur
+	return &t
+}
+
+// TypedCursor is a typed wrapper for Cursor
+type TypedCursor[T Value] struct {
+	cur Cursor
+}
+
+func (c *TypedCursor[T]) assert(value Value, err error) (val T, _ error) {
+	if err != nil {
+		return val, err
+	}
+
+	return value.(T), nil
+}
+
+// Seek will seek the provided ID
+func (c *TypedCursor[T]) Seek(seekID string) (val T, err error) {
+	return c.assert(c.cur.Seek(seekID))
+}
+
+// SeekReverse will seek the provided ID in a reverse direction
+func (c *TypedCursor[T]) SeekReverse(seekID string) (val T, err error) {
+	return c.assert(c.cur.SeekReverse(seekID))
+}
+
+// First will return the first entry
+func (c *TypedCursor[T]) First() (val T, err error) {
+	return c.assert(c.cur.First())
+}
+
+// Last will return the last entry
+func (c *TypedCursor[T]) Last() (val T, err error) {
+	return c.assert(c.cur.Last())
+}
+
+// Next will return the next entry
+func (c *TypedCursor[T]) Next() (val T, err error) {
+	return c.assert(c.cur.Next())
+}
+
+// Prev will return the previous entry
+func (c *TypedCursor[T]) Prev() (val T, err error) {
+	return c.assert(c.cur.Prev())
+}
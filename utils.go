@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log"
+	"os"
+	"path"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/mojura/backend"
+	"github.com/mojura/mojura/filters"
 )
 
 func getReflectedSlice(t reflect.Type, v interface{}) (slice reflect.Value, err error) {
@@ -89,7 +93,7 @@ func parseLogKey(logKey []byte) (bucket, key []byte, err error) {
 func recoverCall(txn *Transaction, fn TransactionFn) (err error) {
 	defer func() {
 		if p := recover(); p != nil {
-			err = fmt.Errorf("panic caught: %v", err)
+			err = fmt.Errorf("panic caught: %v", p)
 		}
 	}()
 
@@ -196,29 +200,263 @@ func getFirst(c Cursor, lastID string, reverse bool) (v Value, err error) {
 	return
 }
 
+const (
+	// seekIDSeparator separates the relationship ID and entry ID of a seek ID
+	seekIDSeparator = "::"
+	// seekIDEscape precedes the escaped characters of a seek ID part
+	seekIDEscape = '\\'
+	// seekIDEscapedChars are the characters which are escaped within a seek ID part
+	seekIDEscapedChars = "\\:"
+)
+
+// splitSeekID will split a seek ID into its relationship ID and entry ID
+// Note: Each part is unescaped, so parts which contain the separator are supported (see joinSeekID)
 func splitSeekID(seekID []byte) (relationshipID, entryID []byte) {
 	if len(seekID) == 0 {
 		return
 	}
 
-	spl := bytes.SplitN(seekID, []byte("::"), 2)
+	index := getSeekIDSeparatorIndex(seekID)
+	if index == -1 {
+		relationshipID = unescapeSeekIDPart(seekID)
+		return
+	}
 
-	// Set relationship ID
-	relationshipID = spl[0]
+	relationshipID = unescapeSeekIDPart(seekID[:index])
+	entryID = seekID[index+len(seekIDSeparator):]
+	if index = getSeekIDSeparatorIndex(entryID); index != -1 {
+		// Seek ID is pinned, the pin is not part of the entry ID (see joinPinnedSeekID)
+		entryID = entryID[:index]
+	}
+
+	entryID = unescapeSeekIDPart(entryID)
+	return
+}
+
+// getSeekIDPin will return the pinned primary index of a seek ID, -1 is returned when the seek ID is not pinned
+func getSeekIDPin(seekID string) (pin int, err error) {
+	pin = -1
+	for i := 0; i < 2; i++ {
+		index := getSeekIDSeparatorIndex([]byte(seekID))
+		if index == -1 {
+			return
+		}
+
+		seekID = seekID[index+len(seekIDSeparator):]
+	}
 
-	if len(spl) == 2 {
-		// Split is a length of 2, set entry ID
-		entryID = spl[1]
+	if pin, err = strconv.Atoi(seekID); err != nil || pin < 0 {
+		pin = -1
+		err = ErrInvalidLastID
 	}
 
 	return
 }
 
+// joinSeekID will join a relationship ID and entry ID into a seek ID
+// Note: Each part is escaped so that parts which contain the separator can be split
 func joinSeekID(relationshipID, entryID string) (seekID string) {
-	return strings.Join([]string{relationshipID, entryID}, "::")
+	return escapeSeekIDPart(relationshipID) + seekIDSeparator + escapeSeekIDPart(entryID)
+}
+
+// joinPinnedSeekID will append the pinned primary index to a seek ID (see queryPlan.getPin)
+func joinPinnedSeekID(seekID string, pin int) (pinnedSeekID string) {
+	return seekID + seekIDSeparator + strconv.Itoa(pin)
+}
+
+// getLastID will return the last ID of the current position of a cursor
+// Note: When the primary of the cursor is pinned, the last ID records it so iteration resumes with the same primary
+func getLastID(c Cursor, entryID string) (lastID string) {
+	lastID = joinSeekID(c.getCurrentRelationshipID(), entryID)
+	if mc, ok := c.(*multiCursor); ok && mc.mid.pin != -1 {
+		lastID = joinPinnedSeekID(lastID, mc.mid.pin)
+	}
+
+	return
+}
+
+// getSeekIDSeparatorIndex will return the index of the first unescaped separator, -1 is returned when none exists
+func getSeekIDSeparatorIndex(seekID []byte) (index int) {
+	for i := 0; i < len(seekID); i++ {
+		switch {
+		case seekID[i] == seekIDEscape:
+			// Skip the escaped character
+			i++
+		case bytes.HasPrefix(seekID[i:], []byte(seekIDSeparator)):
+			return i
+		}
+	}
+
+	return -1
+}
+
+func escapeSeekIDPart(part string) (escaped string) {
+	if !strings.ContainsAny(part, seekIDEscapedChars) {
+		return part
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(part); i++ {
+		if strings.IndexByte(seekIDEscapedChars, part[i]) != -1 {
+			sb.WriteByte(seekIDEscape)
+		}
+
+		sb.WriteByte(part[i])
+	}
+
+	return sb.String()
+}
+
+func unescapeSeekIDPart(part []byte) (unescaped []byte) {
+	if bytes.IndexByte(part, seekIDEscape) == -1 {
+		return part
+	}
+
+	unescaped = make([]byte, 0, len(part))
+	for i := 0; i < len(part); i++ {
+		if part[i] == seekIDEscape && i+1 < len(part) {
+			i++
+		}
+
+		unescaped = append(unescaped, part[i])
+	}
+
+	return
 }
 
 func hasEntries(bkt backend.Bucket) (ok bool) {
 	k, _ := bkt.Cursor().First()
 	return len(k) > 0
 }
+
+// getRoot will return the root bucket getter for a given namespace
+// Note: Buckets implement the methods of backend.Transaction, so a namespaced bucket
+// can be used in place of the transaction
+func getRoot(txn backend.Transaction, namespace []byte) (root backend.Transaction) {
+	if len(namespace) == 0 {
+		return txn
+	}
+
+	var collectionsBkt backend.Bucket
+	if collectionsBkt = txn.GetBucket(collectionsBktKey); collectionsBkt == nil {
+		return nopR
+	}
+
+	var bkt backend.Bucket
+	if bkt = collectionsBkt.GetBucket(namespace); bkt == nil {
+		return nopR
+	}
+
+	return bkt
+}
+
+func getOrCreateRoot(txn backend.Transaction, namespace []byte) (root backend.Transaction, err error) {
+	if len(namespace) == 0 {
+		return txn, nil
+	}
+
+	var collectionsBkt backend.Bucket
+	if collectionsBkt, err = txn.GetOrCreateBucket(collectionsBktKey); err != nil {
+		return
+	}
+
+	return collectionsBkt.GetOrCreateBucket(namespace)
+}
+
+func archiveLog(logsDir, filename string) {
+	var err error
+	archiveDir := path.Join(logsDir, "archived")
+	name := path.Base(filename)
+	destination := path.Join(archiveDir, name)
+
+	if err = os.MkdirAll(archiveDir, 0744); err != nil {
+		// TODO: Add error logging here after we implement output interface to mojura
+		log.Printf("error creating archive directory: %v\n", err)
+		return
+	}
+
+	if err = os.Rename(filename, destination); err != nil {
+		// TODO: Add error logging here after we implement output interface to mojura
+		log.Printf("error renaming file: %v\n", err)
+		return
+	}
+}
+
+func dedupeSortedIDs(ids [][]byte) (out [][]byte) {
+	out = ids[:0]
+	for i, id := range ids {
+		if i > 0 && bytes.Equal(ids[i-1], id) {
+			continue
+		}
+
+		out = append(out, id)
+	}
+
+	return
+}
+
+// getPrefixUpperBound will return the smallest key which is greater than every key beginning with the prefix
+// Note: A nil value is returned when no upper bound exists (prefix is empty or only contains 0xff bytes)
+func getPrefixUpperBound(prefix []byte) (upperBound []byte) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] == 0xff {
+			continue
+		}
+
+		upperBound = make([]byte, i+1)
+		copy(upperBound, prefix)
+		upperBound[i]++
+		return
+	}
+
+	return
+}
+
+func matchAllComparisonFn(relationshipID string) (ok bool, err error) {
+	return true, nil
+}
+
+// describeFilters will return the text form of the filters for logging and explanations
+func describeFilters(fs []Filter) (description string) {
+	var err error
+	if description, err = filters.Format(fs...); err == nil {
+		return
+	}
+
+	// Filters are not all serializable, describe each filter individually
+	parts := make([]string, 0, len(fs))
+	for _, f := range fs {
+		parts = append(parts, describeSingleFilter(f))
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+func describeSingleFilter(f Filter) (description string) {
+	var err error
+	if description, err = filters.Format(f); err == nil {
+		return
+	}
+
+	if _, ok := f.(*filters.PredicateFilter); ok {
+		// Predicates have no text form
+		return "PREDICATE"
+	}
+
+	// Filter is not serializable, fall back to the Go representation
+	return fmt.Sprintf("%+v", f)
+}
+
+func describeFilter(f Filter) (description string) {
+	if f == nil {
+		return "all entries"
+	}
+
+	return describeFilters([]Filter{f})
+}
+
+func reverseValues(vals []Value) {
+	for i, j := 0, len(vals)-1; i < j; i, j = i+1, j-1 {
+		vals[i], vals[j] = vals[j], vals[i]
+	}
+}
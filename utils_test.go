@@ -37,3 +37,62 @@ func Test_stripLeadingZeros(t *testing.T) {
 		}
 	}
 }
+
+func Test_getPrefixUpperBound(t *testing.T) {
+	type testcase struct {
+		value    []byte
+		expected []byte
+	}
+
+	tcs := []testcase{
+		{
+			value:    []byte("org:1"),
+			expected: []byte("org:2"),
+		},
+		{
+			value:    []byte{'a', 0xff},
+			expected: []byte("b"),
+		},
+		{
+			value:    []byte{0xff, 0xff},
+			expected: nil,
+		},
+		{
+			value:    []byte(""),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tcs {
+		var out []byte
+		if out = getPrefixUpperBound(tc.value); !bytes.Equal(tc.expected, out) {
+			t.Fatalf("invalid value, expected %s and received %s", string(tc.expected), out)
+		}
+	}
+}
+
+func Test_splitSeekID(t *testing.T) {
+	type testcase struct {
+		relationshipID string
+		entryID        string
+	}
+
+	tcs := []testcase{
+		{relationshipID: "user_0", entryID: "00000000"},
+		{relationshipID: "", entryID: "00000001"},
+		{relationshipID: "a::b", entryID: "00000002"},
+		{relationshipID: "a:", entryID: "::"},
+		{relationshipID: `a\:b\`, entryID: `c\`},
+	}
+
+	for i, tc := range tcs {
+		relationshipID, entryID := splitSeekID([]byte(joinSeekID(tc.relationshipID, tc.entryID)))
+		if string(relationshipID) != tc.relationshipID {
+			t.Fatalf("invalid relationship ID, expected <%s> and received <%s> (test case #%d)", tc.relationshipID, relationshipID, i)
+		}
+
+		if string(entryID) != tc.entryID {
+			t.Fatalf("invalid entry ID, expected <%s> and received <%s> (test case #%d)", tc.entryID, entryID, i)
+		}
+	}
+}